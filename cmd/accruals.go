@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/akhiltak/pismo-api/config"
+	"github.com/akhiltak/pismo-api/internal/server"
+	"github.com/akhiltak/pismo-api/pkg/api"
+)
+
+// runAccruals charges the interest and late fees of -date (yesterday by default)
+// Meant to be scheduled daily right after `pismo-backend statements`, a rerun for the same day charges nothing more
+func runAccruals(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("accruals", flag.ExitOnError)
+	dateFlag := flags.String("date", "", "day to accrue (YYYY-MM-DD, UTC), has to be over, defaults to yesterday")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	day := time.Now().UTC().AddDate(0, 0, -1)
+	if *dateFlag != "" {
+		var err error
+		if day, err = time.Parse(api.DateLayout, *dateFlag); err != nil {
+			return fmt.Errorf("invalid -date %q, expected YYYY-MM-DD", *dateFlag)
+		}
+	}
+
+	accruals, err := server.NewTransactionService(ctx, cfg).Accrue(ctx, day)
+	log.Printf("%d interest and late fee charges for %s", len(accruals), day.Format(api.DateLayout))
+	return err
+}
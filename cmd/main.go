@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"log/slog"
 	"os"
@@ -26,6 +27,12 @@ func main() {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
 
+	// scheduled commands run the service without the REST server
+	if len(os.Args) > 1 {
+		runCommand(ctx, cfg, os.Args[1], os.Args[2:])
+		return
+	}
+
 	log.Println("Starting Pismo transaction server ...")
 	slog.Info("Starting Pismo transaction server ...")
 
@@ -39,6 +46,22 @@ func main() {
 	waitForShutdown(ctx, s)
 }
 
+// runCommand runs a subcommand of the binary and exits with an error status when it fails
+func runCommand(ctx context.Context, cfg *config.Config, name string, args []string) {
+	var err error
+	switch name {
+	case "statements":
+		err = runStatements(ctx, cfg, args)
+	case "accruals":
+		err = runAccruals(ctx, cfg, args)
+	default:
+		err = fmt.Errorf("unknown command %q, available: statements, accruals", name)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
 // waitForShutdown handles graceful termination
 func waitForShutdown(ctx context.Context, s *server.Server) {
 	quit := make(chan os.Signal, 1)
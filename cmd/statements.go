@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/akhiltak/pismo-api/config"
+	"github.com/akhiltak/pismo-api/internal/server"
+	"github.com/akhiltak/pismo-api/pkg/api"
+)
+
+// runStatements closes the billing cycles ended at or before -as-of (now by default) and generates their statements
+// Meant to be scheduled daily (e.g. `pismo-backend statements` from cron), a run closing nothing is a no-op
+func runStatements(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("statements", flag.ExitOnError)
+	asOfFlag := flags.String("as-of", "", "close the cycles ended at or before this date (YYYY-MM-DD, end of day UTC) or RFC 3339 time, defaults to now")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	asOf, err := parseAsOf(*asOfFlag)
+	if err != nil {
+		return err
+	}
+
+	statements, err := server.NewTransactionService(ctx, cfg).CloseStatements(ctx, asOf)
+	log.Printf("%d statements generated as of %s", len(statements), asOf.Format(time.RFC3339))
+	return err
+}
+
+// parseAsOf reads the -as-of flag of the scheduled commands, a date covers that whole day
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now().UTC(), nil
+	}
+	if date, err := time.Parse(api.DateLayout, value); err == nil {
+		return date.AddDate(0, 0, 1), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -as-of %q, expected YYYY-MM-DD or RFC 3339", value)
+	}
+	return t, nil
+}
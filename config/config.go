@@ -3,15 +3,21 @@ package config
 import (
 	"log"
 	"sync"
+	"time"
 
 	v11env "github.com/caarlos0/env/v11"
 	_ "github.com/joho/godotenv/autoload"
 )
 
 type Config struct {
-	Debug              bool   `env:"DEBUG" envDefault:"false"`
-	PostgresDNS        string `env:"POSTGRES_DNS,required,notEmpty"`
-	HTTPListenHostPort string `env:"HTTP_LISTEN_HOST_PORT" envDefault:"0.0.0.0:2090"`
+	Debug                       bool          `env:"DEBUG" envDefault:"false"`
+	PostgresDNS                 string        `env:"POSTGRES_DNS,required,notEmpty"`
+	HTTPListenHostPort          string        `env:"HTTP_LISTEN_HOST_PORT" envDefault:"0.0.0.0:2090"`
+	IdempotencyKeyTTL           time.Duration `env:"IDEMPOTENCY_KEY_TTL" envDefault:"24h"`           // how long a stored Idempotency-Key response is replayed
+	IdempotencyKeyPurgeInterval time.Duration `env:"IDEMPOTENCY_KEY_PURGE_INTERVAL" envDefault:"1h"` // how often expired Idempotency-Keys are deleted, 0 disables the purge
+	IdempotencyKeyLease         time.Duration `env:"IDEMPOTENCY_KEY_LEASE" envDefault:"1m"`          // how long a request holds its Idempotency-Key before a retry can take it over
+	SchedulerInterval           time.Duration `env:"SCHEDULER_INTERVAL" envDefault:"1m"`             // how often due scheduled transactions are booked, 0 disables the scheduler
+	SchedulerMaxLateness        time.Duration `env:"SCHEDULER_MAX_LATENESS" envDefault:"1h"`         // occurrences missed by more than this are skipped instead of booked, 0 books them all
 }
 
 var instance Config
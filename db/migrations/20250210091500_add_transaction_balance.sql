@@ -0,0 +1,11 @@
+-- migrate:up
+-- remaining (not yet discharged) part of the transaction amount, negative for open debits
+ALTER TABLE transactions ADD COLUMN balance DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+UPDATE transactions SET balance = amount;
+
+CREATE INDEX transactions_open_debits_idx ON transactions (account_id, event_date) WHERE balance < 0;
+
+-- migrate:down
+DROP INDEX IF EXISTS transactions_open_debits_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS balance;
@@ -0,0 +1,7 @@
+-- migrate:up
+-- the limit is opt-in: NULL (existing accounts and accounts opened without one) means debits are not limited
+ALTER TABLE accounts ADD COLUMN available_credit_limit DECIMAL(10, 2) NULL
+    CONSTRAINT accounts_available_credit_limit_check CHECK (available_credit_limit >= 0);
+
+-- migrate:down
+ALTER TABLE accounts DROP COLUMN IF EXISTS available_credit_limit;
@@ -0,0 +1,18 @@
+-- migrate:up
+ALTER TABLE operation_types ADD COLUMN installments BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE operation_types SET installments = true WHERE description = 'Purchase with installments';
+
+CREATE TABLE installments (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    number INT NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    due_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (transaction_id, number)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS installments;
+ALTER TABLE operation_types DROP COLUMN IF EXISTS installments;
@@ -0,0 +1,8 @@
+-- migrate:up
+ALTER TABLE transactions ADD COLUMN reversed_transaction_id INT NULL REFERENCES transactions(id);
+
+CREATE INDEX transactions_reversed_transaction_id_idx ON transactions (reversed_transaction_id) WHERE reversed_transaction_id IS NOT NULL;
+
+-- migrate:down
+DROP INDEX IF EXISTS transactions_reversed_transaction_id_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reversed_transaction_id;
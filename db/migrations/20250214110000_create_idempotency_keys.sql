@@ -0,0 +1,14 @@
+-- migrate:up
+CREATE TABLE idempotency_keys (
+    key VARCHAR(255) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL, -- sha256 of method, path and body of the request
+    response_code INT NULL,         -- NULL while the request is still being processed
+    response_body BYTEA NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+
+-- migrate:down
+DROP TABLE IF EXISTS idempotency_keys;
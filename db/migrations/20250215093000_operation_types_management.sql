@@ -0,0 +1,16 @@
+-- migrate:up
+ALTER TABLE operation_types ADD COLUMN active BOOLEAN NOT NULL DEFAULT true;
+
+-- deleting an operation type must never wipe out the transactions booked with it
+ALTER TABLE transactions
+    DROP CONSTRAINT transactions_operation_type_id_fkey,
+    ADD CONSTRAINT transactions_operation_type_id_fkey FOREIGN KEY (operation_type_id)
+        REFERENCES operation_types(id) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- migrate:down
+ALTER TABLE transactions
+    DROP CONSTRAINT transactions_operation_type_id_fkey,
+    ADD CONSTRAINT transactions_operation_type_id_fkey FOREIGN KEY (operation_type_id)
+        REFERENCES operation_types(id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE operation_types DROP COLUMN IF EXISTS active;
@@ -0,0 +1,64 @@
+-- migrate:up
+-- has_check_digits mirrors api.hasCheckDigits: the two trailing mod 11 check digits of a document of
+-- array_length(weights) + 1 digits, the first one uses the weights without the first
+CREATE FUNCTION pg_temp.has_check_digits(doc TEXT, weights INT[]) RETURNS BOOLEAN LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    n     INT := array_length(weights, 1) + 1;
+    total INT;
+BEGIN
+    IF doc !~ ('^[0-9]{' || n || '}$') OR doc ~ '^(.)\1*$' THEN
+        RETURN FALSE;
+    END IF;
+    FOR k IN 0..1 LOOP
+        total := 0;
+        FOR i IN 1..n - 2 + k LOOP
+            total := total + substr(doc, i, 1)::INT * weights[i + 1 - k];
+        END LOOP;
+        total := total % 11;
+        IF (CASE WHEN total >= 2 THEN 11 - total ELSE 0 END) <> substr(doc, n - 1 + k, 1)::INT THEN
+            RETURN FALSE;
+        END IF;
+    END LOOP;
+    RETURN TRUE;
+END
+$$;
+
+CREATE FUNCTION pg_temp.is_document(doc TEXT) RETURNS BOOLEAN LANGUAGE sql IMMUTABLE AS $$
+    SELECT pg_temp.has_check_digits(doc, ARRAY[11, 10, 9, 8, 7, 6, 5, 4, 3, 2])
+        OR pg_temp.has_check_digits(doc, ARRAY[6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2])
+$$;
+
+-- document numbers are stored without punctuation, so that a formatted and a plain CPF/CNPJ are the same account
+-- values that are not a valid CPF/CNPJ once stripped are kept as they are: they were never checked, so stripping
+-- them could make unrelated values collide, they are reported below
+UPDATE accounts SET document_number = regexp_replace(document_number, '[./ -]', '', 'g')
+WHERE pg_temp.is_document(regexp_replace(document_number, '[./ -]', '', 'g'));
+
+-- accounts sharing a document number cannot be merged automatically (they have their own balances and
+-- transactions), the migration stops and lists them so they can be reconciled before it is run again
+DO $$
+DECLARE
+    report TEXT;
+BEGIN
+    SELECT string_agg(ids, '; ' ORDER BY first_id) INTO report
+    FROM (SELECT min(id) AS first_id, string_agg(id::TEXT, ', ' ORDER BY id) AS ids
+          FROM accounts GROUP BY document_number HAVING count(*) > 1) duplicates;
+    IF report IS NOT NULL THEN
+        RAISE EXCEPTION 'accounts share a document number once its punctuation is stripped, account ids: %', report
+            USING HINT = 'merge or correct the document number of these accounts, then run the migration again';
+    END IF;
+
+    SELECT string_agg(id::TEXT, ', ' ORDER BY id) INTO report FROM accounts WHERE NOT pg_temp.is_document(document_number);
+    IF report IS NOT NULL THEN
+        RAISE WARNING 'document number is not a valid CPF or CNPJ and was kept as is, account ids: %', report;
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX accounts_document_number_key ON accounts (document_number);
+
+DROP FUNCTION pg_temp.is_document(TEXT);
+DROP FUNCTION pg_temp.has_check_digits(TEXT, INT[]);
+
+-- migrate:down
+DROP INDEX IF EXISTS accounts_document_number_key;
@@ -0,0 +1,11 @@
+-- migrate:up
+ALTER TABLE accounts
+    ADD COLUMN status VARCHAR(255) NOT NULL DEFAULT 'active',
+    ADD COLUMN status_reason VARCHAR(255) NULL,
+    ADD COLUMN status_changed_at TIMESTAMPTZ NULL;
+
+-- migrate:down
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
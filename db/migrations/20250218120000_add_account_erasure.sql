@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE accounts
+    ADD COLUMN erased_at TIMESTAMPTZ NULL,
+    ADD COLUMN erased_by VARCHAR(255) NULL;
+
+-- migrate:down
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS erased_by,
+    DROP COLUMN IF EXISTS erased_at;
@@ -0,0 +1,50 @@
+-- migrate:up
+CREATE TABLE customers (
+    id SERIAL PRIMARY KEY,
+    document_number VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NULL,
+    birth_date DATE NULL,
+    erased_at TIMESTAMPTZ NULL,
+    erased_by VARCHAR(255) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX customers_document_number_key ON customers (document_number);
+
+ALTER TABLE accounts ADD COLUMN customer_id INT NULL REFERENCES customers(id) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- backfill one customer per document number, the identity (and its erasure) moves from the accounts to the customer
+INSERT INTO customers (document_number, erased_at, erased_by, created_at, updated_at)
+SELECT document_number, erased_at, erased_by, created_at, updated_at FROM accounts;
+
+UPDATE accounts SET customer_id = customers.id
+FROM customers
+WHERE customers.document_number = accounts.document_number;
+
+ALTER TABLE accounts ALTER COLUMN customer_id SET NOT NULL;
+CREATE INDEX accounts_customer_id_idx ON accounts (customer_id);
+
+DROP INDEX IF EXISTS accounts_document_number_key;
+ALTER TABLE accounts
+    DROP COLUMN document_number,
+    DROP COLUMN erased_at,
+    DROP COLUMN erased_by;
+
+-- migrate:down
+ALTER TABLE accounts
+    ADD COLUMN document_number VARCHAR(255) NULL,
+    ADD COLUMN erased_at TIMESTAMPTZ NULL,
+    ADD COLUMN erased_by VARCHAR(255) NULL;
+
+UPDATE accounts SET document_number = customers.document_number, erased_at = customers.erased_at, erased_by = customers.erased_by
+FROM customers
+WHERE customers.id = accounts.customer_id;
+
+ALTER TABLE accounts ALTER COLUMN document_number SET NOT NULL;
+-- fails if a customer opened several accounts, which the previous schema cannot represent
+CREATE UNIQUE INDEX accounts_document_number_key ON accounts (document_number);
+
+DROP INDEX IF EXISTS accounts_customer_id_idx;
+ALTER TABLE accounts DROP COLUMN customer_id;
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,9 @@
+-- migrate:up
+ALTER TABLE accounts ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+
+-- jsonb_path_ops only supports containment (@>), which is all the metadata filter needs, and is smaller than the default ops
+CREATE INDEX accounts_metadata_idx ON accounts USING GIN (metadata jsonb_path_ops);
+
+-- migrate:down
+DROP INDEX IF EXISTS accounts_metadata_idx;
+ALTER TABLE accounts DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,23 @@
+-- migrate:up
+-- every account and transaction so far is in BRL
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+-- a transaction always takes the currency of its account
+ALTER TABLE transactions ALTER COLUMN currency DROP DEFAULT;
+
+-- room for the currencies with up to 4 decimals (CLF), the decimals of each currency are enforced by the service
+ALTER TABLE accounts ALTER COLUMN available_credit_limit TYPE NUMERIC(19, 4);
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE NUMERIC(19, 4),
+    ALTER COLUMN balance TYPE NUMERIC(19, 4);
+ALTER TABLE installments ALTER COLUMN amount TYPE NUMERIC(19, 4);
+
+-- migrate:down
+ALTER TABLE installments ALTER COLUMN amount TYPE DECIMAL(10, 2);
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE DECIMAL(10, 2),
+    ALTER COLUMN balance TYPE DECIMAL(10, 2);
+ALTER TABLE accounts ALTER COLUMN available_credit_limit TYPE DECIMAL(10, 2);
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE accounts DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,37 @@
+-- migrate:up
+-- needed to mix the equality on the currency pair with the overlap of the validity windows in one exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE fx_rates (
+    id SERIAL PRIMARY KEY,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(19, 8) NOT NULL CHECK (rate > 0),
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fx_rates_valid_window CHECK (valid_to IS NULL OR valid_to > valid_from),
+    -- at most one rate per currency pair at any point in time, valid_to is exclusive and NULL is open ended
+    CONSTRAINT fx_rates_no_overlap EXCLUDE USING gist (
+        base_currency WITH =,
+        quote_currency WITH =,
+        tstzrange(valid_from, valid_to) WITH &&
+    )
+);
+
+-- the amount of a converted transaction is in the currency of the account, the original one is kept for audit
+ALTER TABLE transactions
+    ADD COLUMN original_amount NUMERIC(19, 4) NULL,
+    ADD COLUMN original_currency CHAR(3) NULL,
+    ADD COLUMN fx_rate NUMERIC(19, 8) NULL,
+    ADD COLUMN fx_rate_id INT NULL REFERENCES fx_rates(id) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- migrate:down
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS fx_rate_id,
+    DROP COLUMN IF EXISTS fx_rate,
+    DROP COLUMN IF EXISTS original_currency,
+    DROP COLUMN IF EXISTS original_amount;
+
+DROP TABLE IF EXISTS fx_rates;
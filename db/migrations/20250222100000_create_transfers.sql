@@ -0,0 +1,32 @@
+-- migrate:up
+-- operation types the service books by itself are found by code, they cannot be used directly on POST /transactions
+ALTER TABLE operation_types ADD COLUMN code VARCHAR(64) NULL;
+CREATE UNIQUE INDEX operation_types_code_key ON operation_types (code);
+
+INSERT INTO operation_types (description, entry_type, code) VALUES
+('Transfer Out', 'debit', 'transfer_out'),
+('Transfer In', 'credit', 'transfer_in');
+
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    source_account_id INT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    destination_account_id INT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    amount NUMERIC(19, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    description VARCHAR(255) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT transfers_distinct_accounts CHECK (source_account_id <> destination_account_id)
+);
+
+-- the debit on the source and the credit on the destination both point at their transfer
+ALTER TABLE transactions ADD COLUMN transfer_id INT NULL REFERENCES transfers(id) ON DELETE RESTRICT ON UPDATE CASCADE;
+CREATE INDEX transactions_transfer_id_idx ON transactions (transfer_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS transactions_transfer_id_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+DROP TABLE IF EXISTS transfers;
+
+DELETE FROM operation_types WHERE code IN ('transfer_out', 'transfer_in');
+DROP INDEX IF EXISTS operation_types_code_key;
+ALTER TABLE operation_types DROP COLUMN IF EXISTS code;
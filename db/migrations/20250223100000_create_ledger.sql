@@ -0,0 +1,90 @@
+-- migrate:up
+-- accounts of the books: one customer account per account, and one settlement, fees and fx account per currency
+-- code is the natural key the service finds or creates them by (customer:42, settlement:BRL, fx:USD, ...)
+CREATE TABLE ledger_accounts (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    kind VARCHAR(32) NOT NULL CHECK (kind IN ('customer', 'settlement', 'fees', 'fx')),
+    account_id INT NULL REFERENCES accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT ledger_accounts_code_key UNIQUE (code),
+    CONSTRAINT ledger_accounts_customer_account CHECK ((kind = 'customer') = (account_id IS NOT NULL))
+);
+
+-- one journal entry per posted transaction
+CREATE TABLE journal_entries (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL REFERENCES transactions(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT journal_entries_transaction_id_key UNIQUE (transaction_id)
+);
+
+-- amount is signed: positive for a debit, negative for a credit
+CREATE TABLE postings (
+    id SERIAL PRIMARY KEY,
+    journal_entry_id INT NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    ledger_account_id INT NOT NULL REFERENCES ledger_accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    amount NUMERIC(19, 4) NOT NULL CHECK (amount <> 0),
+    currency CHAR(3) NOT NULL
+);
+CREATE INDEX postings_journal_entry_id_idx ON postings (journal_entry_id);
+CREATE INDEX postings_ledger_account_id_idx ON postings (ledger_account_id);
+
+-- debits and credits of a journal entry must sum to zero in every currency, checked at commit time
+-- so the postings of an entry can be inserted one by one
+CREATE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings
+        WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency
+        HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % does not balance', NEW.journal_entry_id USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER postings_balanced
+    AFTER INSERT OR UPDATE ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- book the transactions posted so far
+INSERT INTO ledger_accounts (code, kind, account_id, currency)
+SELECT 'customer:' || id, 'customer', id, currency FROM accounts;
+
+INSERT INTO ledger_accounts (code, kind, currency)
+SELECT kind || ':' || currency, kind, currency
+FROM (
+    SELECT currency FROM transactions
+    UNION SELECT original_currency FROM transactions WHERE original_currency IS NOT NULL
+) currencies
+CROSS JOIN (VALUES ('settlement'), ('fx')) kinds (kind);
+
+INSERT INTO journal_entries (transaction_id, created_at)
+SELECT id, event_date FROM transactions
+WHERE status IN ('completed', 'reversed', 'partially_refunded') AND amount <> 0;
+
+-- the customer is debited what the transaction takes from the account, the other side goes to settlement,
+-- through the fx accounts when the transaction was converted from another currency
+INSERT INTO postings (journal_entry_id, ledger_account_id, amount, currency)
+SELECT je.id, la.id, p.amount, p.currency
+FROM journal_entries je
+JOIN transactions t ON t.id = je.transaction_id
+CROSS JOIN LATERAL (VALUES
+    ('customer:' || t.account_id, -t.amount, t.currency),
+    (CASE WHEN t.original_amount IS NULL THEN 'settlement:' ELSE 'fx:' END || t.currency, t.amount, t.currency),
+    ('fx:' || t.original_currency, -t.original_amount, t.original_currency),
+    ('settlement:' || t.original_currency, t.original_amount, t.original_currency)
+) p (code, amount, currency)
+JOIN ledger_accounts la ON la.code = p.code
+WHERE p.amount IS NOT NULL;
+
+-- migrate:down
+DROP TABLE IF EXISTS postings;
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
@@ -0,0 +1,20 @@
+-- migrate:up
+-- running totals of the posted transactions of each account, kept up to date by every insert so balance reads
+-- do not depend on the length of the history. Debits are summed as negative amounts like on transactions
+CREATE TABLE account_balances (
+    account_id INT PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    total_credits NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    total_debits NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO account_balances (account_id, total_credits, total_debits)
+SELECT a.id,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.amount > 0), 0),
+    COALESCE(SUM(t.amount) FILTER (WHERE t.amount < 0), 0)
+FROM accounts a
+LEFT JOIN transactions t ON t.account_id = a.id AND t.status IN ('completed', 'reversed', 'partially_refunded')
+GROUP BY a.id;
+
+-- migrate:down
+DROP TABLE IF EXISTS account_balances;
@@ -0,0 +1,37 @@
+-- migrate:up
+-- billing cycle of each account: the cycle closes at 00:00 UTC on the closing day of every month (1 to 28 so every
+-- month has it) and the statement is due due_days after that
+ALTER TABLE accounts
+    ADD COLUMN closing_day SMALLINT NOT NULL DEFAULT 1 CHECK (closing_day BETWEEN 1 AND 28),
+    ADD COLUMN due_days SMALLINT NOT NULL DEFAULT 10 CHECK (due_days BETWEEN 1 AND 60);
+
+CREATE TABLE statements (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    currency CHAR(3) NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    due_date DATE NOT NULL,
+    opening_balance NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    total_credits NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    total_debits NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    closing_balance NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    minimum_payment NUMERIC(24, 4) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT statements_period CHECK (period_end > period_start),
+    -- a cycle is closed once, whatever the number of generator runs
+    CONSTRAINT statements_account_id_period_end_key UNIQUE (account_id, period_end)
+);
+
+-- the posted transactions frozen into a statement
+ALTER TABLE transactions ADD COLUMN statement_id INT NULL REFERENCES statements(id) ON DELETE RESTRICT ON UPDATE CASCADE;
+CREATE INDEX transactions_statement_id_idx ON transactions (statement_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS transactions_statement_id_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS statement_id;
+DROP TABLE IF EXISTS statements;
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS due_days,
+    DROP COLUMN IF EXISTS closing_day;
@@ -0,0 +1,36 @@
+-- migrate:up
+-- monthly interest rate charged on what is still owed of the debits of an operation type once their statement is
+-- past due, NULL (the default) means the debits of the type never accrue interest
+ALTER TABLE operation_types ADD COLUMN interest_rate NUMERIC(7, 6) NULL CHECK (interest_rate BETWEEN 0 AND 1);
+
+INSERT INTO operation_types (description, entry_type, code) VALUES
+('Interest', 'debit', 'interest'),
+('Late Fee', 'debit', 'late_fee');
+
+-- interest and late fees are charged even when they exceed the available credit limit: the limit is then used up and
+-- the rest is owed over the limit, paid back by the next credits before they restore the limit
+ALTER TABLE accounts ADD COLUMN over_limit NUMERIC(19, 4) NOT NULL DEFAULT 0
+    CONSTRAINT accounts_over_limit_check CHECK (over_limit >= 0),
+    ADD CONSTRAINT accounts_over_limit_available_check CHECK (over_limit = 0 OR available_credit_limit = 0);
+
+-- what the accrual job charged, one row per account, kind and day so that a rerun never charges twice
+CREATE TABLE accruals (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    kind VARCHAR(32) NOT NULL CHECK (kind IN ('interest', 'late_fee')),
+    accrual_date DATE NOT NULL,
+    amount NUMERIC(19, 4) NOT NULL CHECK (amount > 0),
+    transaction_id INT NOT NULL REFERENCES transactions(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    statement_id INT NULL REFERENCES statements(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT accruals_account_id_kind_accrual_date_key UNIQUE (account_id, kind, accrual_date),
+    CONSTRAINT accruals_late_fee_statement CHECK ((kind = 'late_fee') = (statement_id IS NOT NULL))
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS accruals;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS over_limit;
+
+DELETE FROM operation_types WHERE code IN ('interest', 'late_fee');
+ALTER TABLE operation_types DROP COLUMN IF EXISTS interest_rate;
@@ -0,0 +1,42 @@
+-- migrate:up
+-- transactions booked by the scheduler of the server, once at start_at or recurring until end_at or max_occurrences
+-- next_run_at is the next occurrence to book, NULL once the schedule is completed or cancelled
+CREATE TABLE scheduled_transactions (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    operation_type_id INT NOT NULL REFERENCES operation_types(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    amount NUMERIC(19, 4) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NULL,
+    frequency VARCHAR(32) NOT NULL CHECK (frequency IN ('once', 'daily', 'weekly', 'monthly', 'cron')),
+    cron_expression VARCHAR(255) NULL,
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ NULL,
+    max_occurrences INT NULL CHECK (max_occurrences > 0),
+    occurrences INT NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMPTZ NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduled_transactions_cron CHECK ((frequency = 'cron') = (cron_expression IS NOT NULL)),
+    CONSTRAINT scheduled_transactions_next_run CHECK ((status = 'active') = (next_run_at IS NOT NULL))
+);
+CREATE INDEX scheduled_transactions_account_id_idx ON scheduled_transactions (account_id);
+CREATE INDEX scheduled_transactions_next_run_at_idx ON scheduled_transactions (next_run_at) WHERE status = 'active';
+
+-- result of every occurrence booked, at most one per occurrence so a retry never books it twice
+-- an occurrence missed by more than the max lateness of the scheduler (e.g. while it was down) is skipped, not booked
+CREATE TABLE scheduled_transaction_executions (
+    id SERIAL PRIMARY KEY,
+    scheduled_transaction_id INT NOT NULL REFERENCES scheduled_transactions(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    status VARCHAR(32) NOT NULL CHECK (status IN ('succeeded', 'failed', 'skipped')),
+    transaction_id INT NULL REFERENCES transactions(id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    error VARCHAR(255) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduled_transaction_executions_occurrence_key UNIQUE (scheduled_transaction_id, scheduled_for),
+    CONSTRAINT scheduled_transaction_executions_result CHECK ((status = 'succeeded') = (transaction_id IS NOT NULL))
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS scheduled_transaction_executions;
+DROP TABLE IF EXISTS scheduled_transactions;
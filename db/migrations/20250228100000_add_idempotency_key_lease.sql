@@ -0,0 +1,9 @@
+-- migrate:up
+-- reserved_at is when the request holding the key started, a key still without a response once its lease is over
+-- (e.g. the server stopped while processing the request) can be taken over by a retry
+ALTER TABLE idempotency_keys ADD COLUMN reserved_at TIMESTAMPTZ NULL;
+UPDATE idempotency_keys SET reserved_at = created_at;
+ALTER TABLE idempotency_keys ALTER COLUMN reserved_at SET NOT NULL;
+
+-- migrate:down
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS reserved_at;
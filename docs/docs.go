@@ -18,6 +18,64 @@ const docTemplate = `{
     "basePath": "{{.BasePath}}",
     "paths": {
         "/accounts": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "GetAccounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "CPF or CNPJ, formatted or not",
+                        "name": "document_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metadata value of key, e.g. metadata[crm_id]=42, can be repeated with other keys",
+                        "name": "metadata[key]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Page-Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            },
             "post": {
                 "consumes": [
                     "application/json"
@@ -53,6 +111,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Response"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -109,9 +173,77 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "UpdateAccount",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateAccountRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
             }
         },
-        "/health": {
+        "/accounts/{id}/balance": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -120,17 +252,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "account"
+                ],
+                "summary": "GetAccountBalance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "healthcheck",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AccountBalanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
                     }
                 }
             }
         },
-        "/transactions": {
+        "/accounts/{id}/statements": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -139,17 +301,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "statement"
+                ],
+                "summary": "GetAccountStatements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "GetTransactions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/Transaction"
-                            }
+                            "$ref": "#/definitions/Page-Statement"
                         }
                     },
                     "400": {
@@ -158,6 +338,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -165,7 +351,93 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/accounts/{id}/transactions": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "GetAccountTransactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "event_date",
+                            "-event_date",
+                            "amount",
+                            "-amount"
+                        ],
+                        "type": "string",
+                        "description": "Sort column, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "operation_type"
+                        ],
+                        "type": "string",
+                        "description": "Embed related records",
+                        "name": "embed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Page-Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/balances/check": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -174,25 +446,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "admin"
                 ],
-                "summary": "CreateTransaction",
+                "summary": "CheckBalances",
                 "parameters": [
                     {
-                        "description": "CreateTransactionRequest",
+                        "description": "CheckBalancesRequest",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateTransactionRequest"
+                            "$ref": "#/definitions/CheckBalancesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Transaction"
+                            "$ref": "#/definitions/CheckBalancesResponse"
                         }
                     },
                     "400": {
@@ -209,56 +480,2109 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "Account": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "CreatedAt with default",
-                    "type": "string"
-                },
-                "document_number": {
-                    "description": "Document number",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "Primary key",
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "description": "UpdatedAt with default",
-                    "type": "string"
-                }
-            }
+        },
+        "/admin/customers/{id}/erase": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "EraseCustomer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "EraseCustomerRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/EraseCustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customer"
+                ],
+                "summary": "CreateCustomer",
+                "parameters": [
+                    {
+                        "description": "CreateCustomerRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCustomerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/{id}/accounts": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customer"
+                ],
+                "summary": "GetCustomerAccounts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Page-Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/fx-rates": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx-rate"
+                ],
+                "summary": "GetFXRates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency converted from",
+                        "name": "base_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency converted to",
+                        "name": "quote_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the rates valid at this time (RFC3339)",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Page-FXRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx-rate"
+                ],
+                "summary": "CreateFXRate",
+                "parameters": [
+                    {
+                        "description": "CreateFXRateRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateFXRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/FXRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "healthcheck",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/ledger/trial-balance": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "GetTrialBalance",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/LedgerAccountBalance"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/operation-types": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operation-type"
+                ],
+                "summary": "GetOperationTypes",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only active or inactive types",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/OperationType"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operation-type"
+                ],
+                "summary": "CreateOperationType",
+                "parameters": [
+                    {
+                        "description": "OperationTypeRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/OperationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/OperationType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/operation-types/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operation-type"
+                ],
+                "summary": "UpdateOperationType",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Operation type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "OperationTypeRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/OperationTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/OperationType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/operation-types/{id}/deactivate": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operation-type"
+                ],
+                "summary": "DeactivateOperationType",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Operation type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/OperationType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transactions": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled-transaction"
+                ],
+                "summary": "CreateScheduledTransaction",
+                "parameters": [
+                    {
+                        "description": "CreateScheduledTransactionRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateScheduledTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/ScheduledTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transactions/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled-transaction"
+                ],
+                "summary": "GetScheduledTransaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Scheduled transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ScheduledTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduled-transactions/{id}/cancel": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scheduled-transaction"
+                ],
+                "summary": "CancelScheduledTransaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Scheduled transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ScheduledTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/statements/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statement"
+                ],
+                "summary": "GetStatement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Statement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Statement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "GetTransactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Operation type ID",
+                        "name": "operation_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event date lower bound (RFC3339, inclusive)",
+                        "name": "event_date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event date upper bound (RFC3339, inclusive)",
+                        "name": "event_date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum absolute amount",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum absolute amount",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Page-Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "CreateTransaction",
+                "parameters": [
+                    {
+                        "description": "CreateTransactionRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/capture": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "CaptureTransaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CaptureTransactionRequest",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/CaptureTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/installments": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "GetInstallments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Installment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/journal-entry": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "GetJournalEntry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/JournalEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/reverse": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "ReverseTransaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReverseTransactionRequest",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ReverseTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/void": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "VoidTransaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "CreateTransfer",
+                "parameters": [
+                    {
+                        "description": "CreateTransferRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "GetTransfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "Account": {
+            "type": "object",
+            "properties": {
+                "available_credit_limit": {
+                    "description": "Limit left for debits, restored by credits, null when debits are not limited",
+                    "type": "string"
+                },
+                "closing_day": {
+                    "description": "Day of the month (1 to 28) the billing cycle closes, at 00:00 UTC",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217, every transaction of the account is in it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "customer": {
+                    "description": "Embedded on request only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Customer"
+                        }
+                    ]
+                },
+                "customer_id": {
+                    "description": "Foreign key to the owning customer",
+                    "type": "integer"
+                },
+                "due_days": {
+                    "description": "Days between the closing of a cycle and the due date of its statement",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "metadata": {
+                    "description": "Free-form client key/values, e.g. a CRM id",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "over_limit": {
+                    "description": "Interest and fees charged beyond a used up limit, paid back by credits first",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "active, blocked or closed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/AccountStatus"
+                        }
+                    ]
+                },
+                "status_changed_at": {
+                    "description": "When the status last changed",
+                    "type": "string"
+                },
+                "status_reason": {
+                    "description": "Reason of the last status change",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt with default",
+                    "type": "string"
+                }
+            }
+        },
+        "AccountBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "description": "credits minus debits",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217 currency of the account",
+                    "type": "string"
+                },
+                "total_credits": {
+                    "description": "sum of all credit transactions",
+                    "type": "string"
+                },
+                "total_debits": {
+                    "description": "sum of all debit transactions (absolute value)",
+                    "type": "string"
+                }
+            }
+        },
+        "AccountStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "blocked",
+                "closed"
+            ],
+            "x-enum-comments": {
+                "AccountStatusBlocked": "frozen for debits, credits are still accepted",
+                "AccountStatusClosed": "no activity at all, kept for history"
+            },
+            "x-enum-varnames": [
+                "AccountStatusActive",
+                "AccountStatusBlocked",
+                "AccountStatusClosed"
+            ]
+        },
+        "BalanceDrift": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "computed_balance": {
+                    "description": "balance summed from the transactions",
+                    "type": "string"
+                },
+                "stored_balance": {
+                    "description": "balance read by GET /accounts/:id/balance",
+                    "type": "string"
+                }
+            }
+        },
+        "CaptureTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "optional, defaults to the authorized amount",
+                    "type": "string",
+                    "example": "10.00"
+                }
+            }
+        },
+        "CheckBalancesRequest": {
+            "type": "object",
+            "properties": {
+                "repair": {
+                    "description": "rebuild the drifted balances from the transactions",
+                    "type": "boolean"
+                }
+            }
+        },
+        "CheckBalancesResponse": {
+            "type": "object",
+            "properties": {
+                "drifts": {
+                    "description": "accounts whose stored balance is wrong, empty when all are consistent",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BalanceDrift"
+                    }
+                },
+                "repaired": {
+                    "description": "whether the drifted balances were rebuilt",
+                    "type": "boolean"
+                }
+            }
         },
         "CreateAccountRequest": {
             "type": "object",
-            "required": [
-                "document_number"
-            ],
             "properties": {
-                "document_number": {
+                "available_credit_limit": {
+                    "description": "optional, debits are not limited when omitted",
+                    "type": "string",
+                    "example": "1000.00"
+                },
+                "closing_day": {
+                    "description": "day of the month the billing cycle closes, defaults to 1",
+                    "type": "integer",
+                    "maximum": 28,
+                    "minimum": 1
+                },
+                "currency": {
+                    "description": "ISO 4217, defaults to BRL",
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "document_number": {
+                    "description": "CPF or CNPJ, punctuation is stripped",
+                    "type": "string"
+                },
+                "due_days": {
+                    "description": "days from the closing to the due date, defaults to 10",
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 1
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "CreateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "birth_date",
+                "document_number",
+                "name"
+            ],
+            "properties": {
+                "birth_date": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "1990-05-17"
+                },
+                "document_number": {
+                    "description": "CPF or CNPJ, punctuation is stripped",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "CreateFXRateRequest": {
+            "type": "object",
+            "required": [
+                "base_currency",
+                "quote_currency",
+                "rate"
+            ],
+            "properties": {
+                "base_currency": {
+                    "description": "ISO 4217, currency converted from (e.g. of a purchase abroad)",
+                    "type": "string"
+                },
+                "quote_currency": {
+                    "description": "ISO 4217, currency converted to (e.g. of the account)",
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "units of quote currency for one unit of base currency",
+                    "type": "string",
+                    "example": "5.4321"
+                },
+                "valid_from": {
+                    "description": "RFC3339, defaults to now",
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "RFC3339, exclusive, open ended when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateScheduledTransactionRequest": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "amount",
+                "frequency",
+                "operation_type_id"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "description": "positive, signed by the operation type",
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "cron_expression": {
+                    "description": "5 field cron expression in UTC, required by the cron frequency only",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "0 9 * * 1-5"
+                },
+                "currency": {
+                    "description": "ISO 4217, defaults to the currency of the account",
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "RFC3339, no occurrence after it when set",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "how often the transaction is booked",
+                    "type": "string",
+                    "enum": [
+                        "once",
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "cron"
+                    ],
+                    "example": "monthly"
+                },
+                "max_occurrences": {
+                    "description": "no more occurrences than this when set",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "operation_type_id": {
+                    "type": "integer"
+                },
+                "start_at": {
+                    "description": "RFC3339, first occurrence (or the earliest one with a cron expression), defaults to now",
+                    "type": "string"
+                }
+            }
+        },
+        "CreateTransactionRequest": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "amount",
+                "operation_type_id"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "capture": {
+                    "description": "capture defaults to true, false only authorizes a debit which has to be captured or voided later",
+                    "type": "boolean"
+                },
+                "currency": {
+                    "description": "ISO 4217, defaults to the currency of the account, another one is converted with the FX rate valid now",
+                    "type": "string"
+                },
+                "first_due_date": {
+                    "description": "defaults to one month after the purchase",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-03-10"
+                },
+                "installment_count": {
+                    "description": "installments are only accepted for operation types that allow them",
+                    "type": "integer",
+                    "maximum": 72,
+                    "minimum": 1
+                },
+                "operation_type_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateTransferRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "destination_account_id",
+                "source_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "positive, in the currency of the accounts",
+                    "type": "string",
+                    "example": "100.50"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "destination_account_id": {
+                    "type": "integer"
+                },
+                "source_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Currency": {
+            "type": "string",
+            "enum": [
+                "BRL"
+            ],
+            "x-enum-varnames": [
+                "DefaultCurrency"
+            ]
+        },
+        "Customer": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "description": "Birth date, unknown for customers created along with an account",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "document_number": {
+                    "description": "CPF or CNPJ without punctuation, unique",
+                    "type": "string"
+                },
+                "erased_at": {
+                    "description": "When the personal data was erased (LGPD)",
+                    "type": "string"
+                },
+                "erased_by": {
+                    "description": "Who requested the erasure",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Full name, unknown for customers created along with an account",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt with default",
+                    "type": "string"
+                }
+            }
+        },
+        "EntryType": {
+            "type": "string",
+            "enum": [
+                "credit",
+                "debit"
+            ],
+            "x-enum-varnames": [
+                "CreditEntry",
+                "DebitEntry"
+            ]
+        },
+        "EraseCustomerRequest": {
+            "type": "object",
+            "required": [
+                "requested_by"
+            ],
+            "properties": {
+                "requested_by": {
+                    "description": "who requested the erasure, kept for audit",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "ExecutionStatus": {
+            "type": "string",
+            "enum": [
+                "succeeded",
+                "failed",
+                "skipped"
+            ],
+            "x-enum-comments": {
+                "ExecutionFailed": "rejected like the request would have been, e.g. insufficient credit limit",
+                "ExecutionSkipped": "missed by more than the max lateness of the scheduler, e.g. while it was down"
+            },
+            "x-enum-varnames": [
+                "ExecutionSucceeded",
+                "ExecutionFailed",
+                "ExecutionSkipped"
+            ]
+        },
+        "FXRate": {
+            "type": "object",
+            "properties": {
+                "base_currency": {
+                    "description": "ISO 4217, currency converted from",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "quote_currency": {
+                    "description": "ISO 4217, currency converted to",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "rate": {
+                    "description": "Units of quote currency for one unit of base currency",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt with default",
+                    "type": "string"
+                },
+                "valid_from": {
+                    "description": "Start of the validity window, inclusive",
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "End of the validity window, exclusive, open ended when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "Installment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Installment amount, same sign as the purchase",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "due_date": {
+                    "description": "Date the installment is due",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "number": {
+                    "description": "Position in the schedule, starting at 1",
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "description": "Foreign key to parent purchase",
+                    "type": "integer"
+                }
+            }
+        },
+        "JournalEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "postings": {
+                    "description": "Debits and credits of the entry",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Posting"
+                    }
+                },
+                "transaction_id": {
+                    "description": "Foreign key to the booked transaction",
+                    "type": "integer"
+                }
+            }
+        },
+        "LedgerAccount": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "Foreign key to the customer account, customer kind only",
+                    "type": "integer"
+                },
+                "code": {
+                    "description": "Natural key, e.g. customer:42 or settlement:BRL",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217, every posting of the account is in it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "customer, settlement, fees or fx",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/LedgerAccountKind"
+                        }
+                    ]
+                }
+            }
+        },
+        "LedgerAccountBalance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "debits minus credits",
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "credits": {
+                    "description": "sum of negative amounts, as a positive number",
+                    "type": "string"
+                },
+                "currency": {
+                    "$ref": "#/definitions/Currency"
+                },
+                "debits": {
+                    "description": "sum of positive amounts",
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/LedgerAccountKind"
+                },
+                "ledger_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "LedgerAccountKind": {
+            "type": "string",
+            "enum": [
+                "customer",
+                "settlement",
+                "fees",
+                "fx"
+            ],
+            "x-enum-comments": {
+                "LedgerCustomer": "what a customer account owes (debit) or is owed (credit)",
+                "LedgerFX": "position taken converting between currencies, per currency",
+                "LedgerFees": "fees and interest earned, per currency",
+                "LedgerSettlement": "money settled with the outside world (card networks, banks), per currency"
+            },
+            "x-enum-varnames": [
+                "LedgerCustomer",
+                "LedgerSettlement",
+                "LedgerFees",
+                "LedgerFX"
+            ]
+        },
+        "OperationType": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "inactive types cannot be used for new transactions",
+                    "type": "boolean"
+                },
+                "code": {
+                    "description": "set on the types reserved to the service, e.g. transfers",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "installments": {
+                    "description": "whether the amount can be split in installments",
+                    "type": "boolean"
+                },
+                "interest_rate": {
+                    "description": "monthly rate charged on the overdue debits of the type, none when nil",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "type (credit/debit)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/EntryType"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "description": "UpdatedAt with default",
+                    "type": "string"
+                }
+            }
+        },
+        "OperationTypeRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "installments": {
+                    "description": "whether the amount can be split in installments",
+                    "type": "boolean"
+                },
+                "interest_rate": {
+                    "description": "monthly interest rate (0.12 for 12%) charged on what is still owed of the debits of the type once their statement is past due",
+                    "type": "string",
+                    "example": "0.12"
+                },
+                "type": {
+                    "description": "credit or debit",
+                    "type": "string"
+                }
+            }
+        },
+        "Page-Account": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Account"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "Page-FXRate": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FXRate"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "Page-Statement": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Statement"
+                    }
+                },
+                "next_cursor": {
                     "type": "string"
                 }
             }
         },
-        "CreateTransactionRequest": {
+        "Page-Transaction": {
             "type": "object",
-            "required": [
-                "account_id",
-                "amount",
-                "operation_type_id"
-            ],
             "properties": {
-                "account_id": {
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Transaction"
+                    }
                 },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "Posting": {
+            "type": "object",
+            "properties": {
                 "amount": {
-                    "type": "number"
+                    "description": "Positive for a debit, negative for a credit",
+                    "type": "string"
                 },
-                "operation_type_id": {
+                "currency": {
+                    "description": "ISO 4217, the currency of the ledger account",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "journal_entry_id": {
+                    "description": "Foreign key to the journal entry",
+                    "type": "integer"
+                },
+                "ledger_account": {
+                    "description": "Ledger account posted to",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/LedgerAccount"
+                        }
+                    ]
+                },
+                "ledger_account_id": {
+                    "description": "Foreign key to the ledger account",
                     "type": "integer"
                 }
             }
@@ -277,6 +2601,248 @@ const docTemplate = `{
                 }
             }
         },
+        "ReverseTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "optional, defaults to the full amount left to refund",
+                    "type": "string",
+                    "example": "10.00"
+                }
+            }
+        },
+        "ScheduleFrequency": {
+            "type": "string",
+            "enum": [
+                "once",
+                "daily",
+                "weekly",
+                "monthly",
+                "cron"
+            ],
+            "x-enum-comments": {
+                "FrequencyCron": "at every time matching cron_expression (UTC) from start_at on",
+                "FrequencyDaily": "every day at the time of start_at",
+                "FrequencyMonthly": "every month on the day of start_at, the last day of shorter months",
+                "FrequencyOnce": "a single future-dated transaction, at start_at",
+                "FrequencyWeekly": "every week on the weekday and time of start_at"
+            },
+            "x-enum-varnames": [
+                "FrequencyOnce",
+                "FrequencyDaily",
+                "FrequencyWeekly",
+                "FrequencyMonthly",
+                "FrequencyCron"
+            ]
+        },
+        "ScheduleStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "completed",
+                "cancelled"
+            ],
+            "x-enum-comments": {
+                "ScheduleActive": "occurrences are still to be booked",
+                "ScheduleCancelled": "cancelled by the client, no more occurrences",
+                "ScheduleCompleted": "the last occurrence was booked, end_at or max_occurrences reached"
+            },
+            "x-enum-varnames": [
+                "ScheduleActive",
+                "ScheduleCompleted",
+                "ScheduleCancelled"
+            ]
+        },
+        "ScheduledExecution": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why the transaction was rejected, when failed",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "scheduled_for": {
+                    "description": "Occurrence booked, unique per scheduled transaction",
+                    "type": "string"
+                },
+                "scheduled_transaction_id": {
+                    "description": "Foreign key to the scheduled transaction",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "succeeded, failed or skipped",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ExecutionStatus"
+                        }
+                    ]
+                },
+                "transaction_id": {
+                    "description": "Transaction booked, when succeeded",
+                    "type": "integer"
+                }
+            }
+        },
+        "ScheduledTransaction": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "Foreign key to account",
+                    "type": "integer"
+                },
+                "amount": {
+                    "description": "Amount of every occurrence, positive, signed by the operation type",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "cron_expression": {
+                    "description": "5 field cron expression, cron frequency only",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217, the currency of the account when empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "end_at": {
+                    "description": "No occurrence after it when set",
+                    "type": "string"
+                },
+                "executions": {
+                    "description": "Result of every occurrence booked",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ScheduledExecution"
+                    }
+                },
+                "frequency": {
+                    "description": "once, daily, weekly, monthly or cron",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ScheduleFrequency"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "max_occurrences": {
+                    "description": "No more occurrences than this when set",
+                    "type": "integer"
+                },
+                "next_run_at": {
+                    "description": "Next occurrence to book, none once completed or cancelled",
+                    "type": "string"
+                },
+                "occurrences": {
+                    "description": "Occurrences booked so far, succeeded, failed or skipped",
+                    "type": "integer"
+                },
+                "operation_type_id": {
+                    "description": "Foreign key to OperationType",
+                    "type": "integer"
+                },
+                "start_at": {
+                    "description": "First occurrence, or the earliest one with a cron expression",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "active, completed or cancelled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/ScheduleStatus"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "description": "UpdatedAt with default",
+                    "type": "string"
+                }
+            }
+        },
+        "Statement": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "Foreign key to account",
+                    "type": "integer"
+                },
+                "closing_balance": {
+                    "description": "Opening balance plus credits minus debits, negative when owed",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217, the currency of the account",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "due_date": {
+                    "description": "Date the minimum payment is due",
+                    "type": "string",
+                    "format": "date"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "minimum_payment": {
+                    "description": "Part of the owed closing balance to pay by the due date",
+                    "type": "string"
+                },
+                "opening_balance": {
+                    "description": "Closing balance of the previous statement, negative when owed",
+                    "type": "string"
+                },
+                "period_end": {
+                    "description": "Closing of the cycle, exclusive",
+                    "type": "string"
+                },
+                "period_start": {
+                    "description": "Start of the cycle, inclusive (end of the previous one)",
+                    "type": "string"
+                },
+                "total_credits": {
+                    "description": "Sum of the credits of the cycle",
+                    "type": "string"
+                },
+                "total_debits": {
+                    "description": "Sum of the debits of the cycle (absolute value)",
+                    "type": "string"
+                },
+                "transactions": {
+                    "description": "Posted transactions of the cycle, on GET /statements/:id",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Transaction"
+                    }
+                },
+                "updated_at": {
+                    "description": "UpdatedAt with default",
+                    "type": "string"
+                }
+            }
+        },
         "Transaction": {
             "type": "object",
             "properties": {
@@ -286,20 +2852,75 @@ const docTemplate = `{
                 },
                 "amount": {
                     "description": "Transaction amount",
-                    "type": "number"
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "Remaining amount not yet discharged (negative for open debits)",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217, always the currency of the account",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
                 },
                 "event_date": {
                     "description": "CreatedAt with default, called EventDate due to assignment instructions",
                     "type": "string"
                 },
+                "fx_rate": {
+                    "description": "Rate applied, Amount is OriginalAmount times FXRate rounded to the account currency",
+                    "type": "string"
+                },
+                "fx_rate_id": {
+                    "description": "FX rate record the conversion used",
+                    "type": "integer"
+                },
                 "id": {
                     "description": "Primary key",
                     "type": "integer"
                 },
+                "installments": {
+                    "description": "Installment schedule of a purchase with installments",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Installment"
+                    }
+                },
                 "operationTypeID": {
                     "description": "Foreign key to OperationType",
                     "type": "integer"
                 },
+                "operation_type": {
+                    "description": "Embedded on request only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/OperationType"
+                        }
+                    ]
+                },
+                "original_amount": {
+                    "description": "Amount in the currency of a purchase made in another currency, before conversion",
+                    "type": "string"
+                },
+                "original_currency": {
+                    "description": "ISO 4217 currency of the purchase, only when it differs from the account",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "reversed_transaction_id": {
+                    "description": "Transaction compensated by this one (reversals and refunds only)",
+                    "type": "integer"
+                },
+                "statement_id": {
+                    "description": "Statement the transaction is frozen into once its billing cycle is closed",
+                    "type": "integer"
+                },
                 "status": {
                     "description": "status",
                     "allOf": [
@@ -308,25 +2929,106 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "transfer_id": {
+                    "description": "Transfer this debit or credit is one side of",
+                    "type": "integer"
+                },
                 "updated_at": {
                     "description": "UpdatedAt with default",
                     "type": "string"
                 }
             }
         },
+        "Transfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount moved, always positive",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt with default",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "ISO 4217, the currency of both accounts",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/Currency"
+                        }
+                    ]
+                },
+                "description": {
+                    "description": "Free text given by the client",
+                    "type": "string"
+                },
+                "destination_account_id": {
+                    "description": "Foreign key to the credited account",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Primary key",
+                    "type": "integer"
+                },
+                "source_account_id": {
+                    "description": "Foreign key to the debited account",
+                    "type": "integer"
+                },
+                "transactions": {
+                    "description": "The debit and the credit booked for the transfer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Transaction"
+                    }
+                }
+            }
+        },
         "TxnStatus": {
             "type": "string",
             "enum": [
                 "pending",
                 "completed",
-                "failed"
+                "failed",
+                "reversed",
+                "partially_refunded"
             ],
+            "x-enum-comments": {
+                "TxnStatusPartiallyRefunded": "part of the amount refunded by compensating transactions",
+                "TxnStatusReversed": "fully reversed/refunded by compensating transactions"
+            },
             "x-enum-varnames": [
                 "TxnStatusPending",
                 "TxnStatusCompleted",
-                "TxnStatusFailed"
+                "TxnStatusFailed",
+                "TxnStatusReversed",
+                "TxnStatusPartiallyRefunded"
             ]
         },
+        "UpdateAccountRequest": {
+            "type": "object",
+            "properties": {
+                "metadata": {
+                    "description": "replaces the whole metadata, {} clears it",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "description": "why the status changed, kept on the account",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "blocked",
+                        "closed"
+                    ]
+                }
+            }
+        },
         "echo.HTTPError": {
             "type": "object",
             "properties": {
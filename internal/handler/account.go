@@ -20,6 +20,7 @@ import (
 //	@Param		request	body		api.CreateAccountRequest	true	"CreateAccountRequest"
 //	@Success	201		{object}	models.Account
 //	@Failure	400		{object}	api.Response
+//	@Failure	422		{object}	api.Response
 //	@Failure	500		{object}	api.Response
 //	@Router		/accounts [post]
 func (h *handler) CreateAccount(c echo.Context) error {
@@ -36,6 +37,36 @@ func (h *handler) CreateAccount(c echo.Context) error {
 	return c.JSON(http.StatusCreated, account)
 }
 
+// GetAccounts godoc
+//
+//	@Summary	GetAccounts
+//	@Schemes	http https
+//	@Tags		account
+//	@Accept		json
+//	@Produce	json
+//	@Param		document_number	query		string	false	"CPF or CNPJ, formatted or not"
+//	@Param		metadata[key]	query		string	false	"Metadata value of key, e.g. metadata[crm_id]=42, can be repeated with other keys"
+//	@Param		cursor			query		string	false	"next_cursor of the previous page"
+//	@Param		limit			query		int		false	"Page size (max 100)"
+//	@Success	200				{object}	api.Page[models.Account]
+//	@Failure	400				{object}	api.Response
+//	@Failure	500				{object}	api.Response
+//	@Router		/accounts [get]
+func (h *handler) GetAccounts(c echo.Context) error {
+	req := &api.ListAccountsRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	req.Metadata = queryMap(c, "metadata")
+	slog.Debug("GetAccounts", "req", *req)
+
+	accounts, err := h.transactionService.GetAccounts(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, accounts)
+}
+
 // GetAccountByID godoc
 //
 //	@Summary	GetAccountByID
@@ -64,3 +95,131 @@ func (h *handler) GetAccountByID(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, account)
 }
+
+// UpdateAccount godoc
+//
+//	@Summary	UpdateAccount
+//	@Schemes	http https
+//	@Tags		account
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int							true	"Account ID"
+//	@Param		request	body		api.UpdateAccountRequest	true	"UpdateAccountRequest"
+//	@Success	200		{object}	models.Account
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/accounts/{id} [patch]
+func (h *handler) UpdateAccount(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("UpdateAccount", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.UpdateAccountRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	account, err := h.transactionService.UpdateAccount(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, account)
+}
+
+// GetAccountBalance godoc
+//
+//	@Summary	GetAccountBalance
+//	@Schemes	http https
+//	@Tags		account
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Account ID"
+//	@Success	200	{object}	api.AccountBalanceResponse
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/accounts/{id}/balance [get]
+func (h *handler) GetAccountBalance(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetAccountBalance", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	balance, err := h.transactionService.GetBalance(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, balance)
+}
+
+// CheckBalances godoc
+//
+//	@Summary	CheckBalances
+//	@Schemes	http https
+//	@Tags		admin
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		api.CheckBalancesRequest	false	"CheckBalancesRequest"
+//	@Success	200		{object}	api.CheckBalancesResponse
+//	@Failure	400		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/admin/balances/check [post]
+func (h *handler) CheckBalances(c echo.Context) error {
+	req := &api.CheckBalancesRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("CheckBalances", "request", *req)
+
+	res, err := h.transactionService.CheckBalances(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// GetAccountTransactions godoc
+//
+//	@Summary	GetAccountTransactions
+//	@Schemes	http https
+//	@Tags		account
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int		true	"Account ID"
+//	@Param		sort	query		string	false	"Sort column, prefix with - for descending"	Enums(id, -id, event_date, -event_date, amount, -amount)
+//	@Param		embed	query		string	false	"Embed related records"						Enums(operation_type)
+//	@Param		cursor	query		string	false	"next_cursor of the previous page"
+//	@Param		limit	query		int		false	"Page size (max 100)"
+//	@Success	200		{object}	api.Page[models.Transaction]
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/accounts/{id}/transactions [get]
+func (h *handler) GetAccountTransactions(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetAccountTransactions", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.ListAccountTransactionsRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	transactions, err := h.transactionService.GetAccountTransactions(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, transactions)
+}
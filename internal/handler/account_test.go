@@ -11,6 +11,7 @@ import (
 	"github.com/akhiltak/pismo-api/internal/storage/models"
 	"github.com/akhiltak/pismo-api/pkg/api"
 	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -25,15 +26,16 @@ func TestCreateAccount(t *testing.T) {
 	e := echo.New()
 
 	t.Run("successful creation", func(t *testing.T) {
-		reqBody := `{"document_number":"12345678"}`
+		reqBody := `{"document_number":"12345678143"}`
 		req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
 		mockService.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Return(&models.Account{
-			ID:     1,
-			DocNum: "12345678",
+			ID:         1,
+			CustomerID: 7,
+			Customer:   &models.Customer{ID: 7, DocNum: "12345678143"},
 		}, nil)
 
 		if assert.NoError(t, h.CreateAccount(c)) {
@@ -42,10 +44,27 @@ func TestCreateAccount(t *testing.T) {
 			err := json.Unmarshal(rec.Body.Bytes(), &response)
 			assert.NoError(t, err)
 			assert.Equal(t, int64(1), response.ID)
-			assert.Equal(t, "12345678", response.DocNum)
+			assert.Equal(t, int64(7), response.CustomerID)
+			assert.Equal(t, "12345678143", response.Customer.DocNum)
 		}
 	})
 
+	t.Run("for an existing customer", func(t *testing.T) {
+		reqBody := `{"customer_id":7,"metadata":{"crm_id":"42"}}`
+		req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().CreateAccount(gomock.Any(), &api.CreateAccountRequest{CustomerID: 7, Metadata: map[string]string{"crm_id": "42"}}).Return(&models.Account{
+			ID:         2,
+			CustomerID: 7,
+		}, nil)
+
+		assert.NoError(t, h.CreateAccount(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
 	t.Run("invalid request", func(t *testing.T) {
 		reqBody := `{"document_number":""}`
 		req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(reqBody))
@@ -60,8 +79,40 @@ func TestCreateAccount(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, he.Code)
 	})
 
+	t.Run("formatted CNPJ", func(t *testing.T) {
+		reqBody := `{"document_number":"11.222.333/0001-81"}`
+		req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().CreateAccount(gomock.Any(), &api.CreateAccountRequest{DocNum: "11.222.333/0001-81"}).Return(&models.Account{
+			ID:       3,
+			Customer: &models.Customer{DocNum: "11222333000181"},
+		}, nil)
+
+		assert.NoError(t, h.CreateAccount(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("invalid document number", func(t *testing.T) {
+		for _, doc := range []string{"abc", "12345678", "12345678900", "11111111111", "11222333000182"} {
+			req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(`{"document_number":"`+doc+`"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.CreateAccount(c)
+			assert.Error(t, err, doc)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Contains(t, he.Message, "not a valid CPF or CNPJ document number")
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
-		reqBody := `{"document_number":"12345678"}`
+		reqBody := `{"document_number":"12345678143"}`
 		req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(reqBody))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
@@ -77,6 +128,67 @@ func TestCreateAccount(t *testing.T) {
 	})
 }
 
+func TestGetAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("by document number", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?document_number=529.982.247-25&limit=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetAccounts(gomock.Any(), &api.ListAccountsRequest{DocNum: "529.982.247-25", Limit: 10}).Return(&api.Page[*models.Account]{
+			Items: []*models.Account{{ID: 1, Customer: &models.Customer{DocNum: "52998224725"}}},
+		}, nil)
+
+		if assert.NoError(t, h.GetAccounts(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response api.Page[*models.Account]
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Items, 1)
+			assert.Equal(t, "52998224725", response.Items[0].Customer.DocNum)
+		}
+	})
+
+	t.Run("by metadata", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?metadata[crm_id]=42&metadata%5Bproduct%5D=gold&metadata=x", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetAccounts(gomock.Any(), &api.ListAccountsRequest{Metadata: map[string]string{"crm_id": "42", "product": "gold"}}).
+			Return(&api.Page[*models.Account]{Items: []*models.Account{{ID: 2}}}, nil)
+
+		assert.NoError(t, h.GetAccounts(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("limit bounds", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?limit=0", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetAccounts(gomock.Any(), &api.ListAccountsRequest{}).Return(&api.Page[*models.Account]{}, nil)
+
+		assert.NoError(t, h.GetAccounts(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		req = httptest.NewRequest(http.MethodGet, "/accounts?limit=101", nil)
+		c = e.NewContext(req, httptest.NewRecorder())
+
+		err := h.GetAccounts(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
 func TestGetAccountByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -95,8 +207,8 @@ func TestGetAccountByID(t *testing.T) {
 		c.SetParamValues("1")
 
 		mockService.EXPECT().GetAccountByID(gomock.Any(), int64(1)).Return(&models.Account{
-			ID:     1,
-			DocNum: "12345678",
+			ID:       1,
+			Customer: &models.Customer{ID: 7, DocNum: "12345678143"},
 		}, nil)
 
 		if assert.NoError(t, h.GetAccountByID(c)) {
@@ -105,7 +217,7 @@ func TestGetAccountByID(t *testing.T) {
 			err := json.Unmarshal(rec.Body.Bytes(), &response)
 			assert.NoError(t, err)
 			assert.Equal(t, int64(1), response.ID)
-			assert.Equal(t, "12345678", response.DocNum)
+			assert.Equal(t, "12345678143", response.Customer.DocNum)
 		}
 	})
 
@@ -141,3 +253,263 @@ func TestGetAccountByID(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, he.Code) // 500 status code, internal server error
 	})
 }
+
+func TestUpdateAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	newContext := func(id, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPatch, "/accounts/"+id, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c, rec
+	}
+
+	t.Run("block account", func(t *testing.T) {
+		c, rec := newContext("1", `{"status":"blocked","reason":"card stolen"}`)
+
+		mockService.EXPECT().UpdateAccount(gomock.Any(), int64(1), &api.UpdateAccountRequest{Status: "blocked", Reason: "card stolen"}).
+			Return(&models.Account{ID: 1, Status: models.AccountStatusBlocked, StatusReason: "card stolen"}, nil)
+
+		if assert.NoError(t, h.UpdateAccount(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response models.Account
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, models.AccountStatusBlocked, response.Status)
+		}
+	})
+
+	t.Run("metadata only", func(t *testing.T) {
+		c, rec := newContext("1", `{"metadata":{"crm_id":"42"}}`)
+
+		mockService.EXPECT().UpdateAccount(gomock.Any(), int64(1), &api.UpdateAccountRequest{Metadata: map[string]string{"crm_id": "42"}}).
+			Return(&models.Account{ID: 1, Status: models.AccountStatusActive, Metadata: map[string]string{"crm_id": "42"}}, nil)
+
+		if assert.NoError(t, h.UpdateAccount(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response models.Account
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, "42", response.Metadata["crm_id"])
+		}
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for _, body := range []string{
+			`{"metadata":{"":"42"}}`,
+			`{"metadata":{"crm_id":"` + strings.Repeat("x", 256) + `"}}`,
+			`{"metadata":{"crm_id":42}}`,
+		} {
+			c, _ := newContext("1", body)
+
+			err := h.UpdateAccount(c)
+			assert.Error(t, err, body)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+		}
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		c, _ := newContext("1", `{"status":"frozen","reason":"card stolen"}`)
+
+		err := h.UpdateAccount(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		c, _ := newContext("1", `{"status":"blocked"}`)
+
+		err := h.UpdateAccount(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		c, _ := newContext("invalid", `{"status":"blocked","reason":"card stolen"}`)
+
+		err := h.UpdateAccount(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestGetAccountBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1/balance", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/balance")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(&api.AccountBalanceResponse{
+			AccountID:    1,
+			Balance:      money(49.50),
+			TotalCredits: money(150),
+			TotalDebits:  money(100.50),
+		}, nil)
+
+		if assert.NoError(t, h.GetAccountBalance(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response api.AccountBalanceResponse
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), response.AccountID)
+			assert.True(t, decimal.NewFromFloat(49.50).Equal(response.Balance.Decimal))
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/invalid/balance", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/balance")
+		c.SetParamNames("id")
+		c.SetParamValues("invalid")
+
+		err := h.GetAccountBalance(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1/balance", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/balance")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(nil, api.ServerErr(nil))
+
+		err := h.GetAccountBalance(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+	})
+}
+
+func TestCheckBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/balances/check", strings.NewReader(`{"repair":true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.EXPECT().CheckBalances(gomock.Any(), &api.CheckBalancesRequest{Repair: true}).Return(&api.CheckBalancesResponse{
+		Drifts:   []*api.BalanceDrift{{AccountID: 3, StoredBalance: money(60), ComputedBalance: money(45)}},
+		Repaired: true,
+	}, nil)
+
+	if assert.NoError(t, h.CheckBalances(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"computed_balance":"45"`)
+	}
+}
+
+func TestGetAccountTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions?sort=-event_date&embed=operation_type&limit=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/transactions")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().GetAccountTransactions(gomock.Any(), int64(1), &api.ListAccountTransactionsRequest{
+			Sort:  "-event_date",
+			Embed: "operation_type",
+			Limit: 10,
+		}).Return(&api.Page[*models.Transaction]{
+			Items: []*models.Transaction{{
+				ID:            1,
+				AccountID:     1,
+				Amount:        money(-50),
+				OperationType: &models.OperationType{ID: 1, Description: "Normal Purchase", EntryType: models.DebitEntry},
+			}},
+		}, nil)
+
+		if assert.NoError(t, h.GetAccountTransactions(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response api.Page[*models.Transaction]
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Items, 1)
+			assert.Equal(t, "Normal Purchase", response.Items[0].OperationType.Description)
+		}
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1/transactions?sort=balance", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/transactions")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := h.GetAccountTransactions(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/invalid/transactions", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/transactions")
+		c.SetParamNames("id")
+		c.SetParamValues("invalid")
+
+		err := h.GetAccountTransactions(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateCustomer godoc
+//
+//	@Summary	CreateCustomer
+//	@Schemes	http https
+//	@Tags		customer
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		api.CreateCustomerRequest	true	"CreateCustomerRequest"
+//	@Success	201		{object}	models.Customer
+//	@Failure	400		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/customers [post]
+func (h *handler) CreateCustomer(c echo.Context) error {
+	req := &api.CreateCustomerRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("CreateCustomer", "document_number", req.DocNum)
+
+	customer, err := h.transactionService.CreateCustomer(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusCreated, customer)
+}
+
+// GetCustomerAccounts godoc
+//
+//	@Summary	GetCustomerAccounts
+//	@Schemes	http https
+//	@Tags		customer
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int		true	"Customer ID"
+//	@Param		cursor	query		string	false	"next_cursor of the previous page"
+//	@Param		limit	query		int		false	"Page size (max 100)"
+//	@Success	200		{object}	api.Page[models.Account]
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/customers/{id}/accounts [get]
+func (h *handler) GetCustomerAccounts(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetCustomerAccounts", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.ListCustomerAccountsRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	accounts, err := h.transactionService.GetCustomerAccounts(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, accounts)
+}
+
+// EraseCustomer godoc
+//
+//	@Summary	EraseCustomer
+//	@Schemes	http https
+//	@Tags		admin
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int							true	"Customer ID"
+//	@Param		request	body		api.EraseCustomerRequest	true	"EraseCustomerRequest"
+//	@Success	200		{object}	models.Customer
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/admin/customers/{id}/erase [post]
+func (h *handler) EraseCustomer(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("EraseCustomer", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.EraseCustomerRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	customer, err := h.transactionService.EraseCustomer(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, customer)
+}
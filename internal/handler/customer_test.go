@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	newContext := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/customers", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		return e.NewContext(req, rec), rec
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		c, rec := newContext(`{"document_number":"529.982.247-25","name":"Maria Silva","birth_date":"1990-05-17"}`)
+
+		birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+		mockService.EXPECT().CreateCustomer(gomock.Any(), &api.CreateCustomerRequest{
+			DocNum:    "529.982.247-25",
+			Name:      "Maria Silva",
+			BirthDate: &api.Date{Time: birthDate},
+		}).Return(&models.Customer{ID: 1, DocNum: "52998224725", Name: "Maria Silva", BirthDate: &birthDate}, nil)
+
+		if assert.NoError(t, h.CreateCustomer(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			var response models.Customer
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), response.ID)
+			assert.Equal(t, "52998224725", response.DocNum)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, body := range []string{
+			`{"name":"Maria Silva","birth_date":"1990-05-17"}`,
+			`{"document_number":"52998224726","name":"Maria Silva","birth_date":"1990-05-17"}`,
+			`{"document_number":"52998224725","birth_date":"1990-05-17"}`,
+			`{"document_number":"52998224725","name":"Maria Silva"}`,
+			`{"document_number":"52998224725","name":"Maria Silva","birth_date":"17/05/1990"}`,
+		} {
+			c, _ := newContext(body)
+
+			err := h.CreateCustomer(c)
+			assert.Error(t, err, body)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+		}
+	})
+}
+
+func TestGetCustomerAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	newContext := func(id, query string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/customers/"+id+"/accounts"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/customers/:id/accounts")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c, rec
+	}
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		c, rec := newContext("7", "?limit=2")
+
+		mockService.EXPECT().GetCustomerAccounts(gomock.Any(), int64(7), &api.ListCustomerAccountsRequest{Limit: 2}).Return(&api.Page[*models.Account]{
+			Items:      []*models.Account{{ID: 1, CustomerID: 7}, {ID: 2, CustomerID: 7}},
+			NextCursor: "next",
+		}, nil)
+
+		if assert.NoError(t, h.GetCustomerAccounts(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response api.Page[*models.Account]
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response.Items, 2)
+			assert.Equal(t, "next", response.NextCursor)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		c, _ := newContext("invalid", "")
+
+		err := h.GetCustomerAccounts(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestEraseCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	newContext := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/customers/1/erase", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/admin/customers/:id/erase")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return c, rec
+	}
+
+	t.Run("successful erasure", func(t *testing.T) {
+		c, rec := newContext(`{"requested_by":"dpo@example.com"}`)
+
+		erasedAt := time.Now().UTC()
+		mockService.EXPECT().EraseCustomer(gomock.Any(), int64(1), &api.EraseCustomerRequest{RequestedBy: "dpo@example.com"}).Return(&models.Customer{
+			ID:       1,
+			DocNum:   models.ErasedPlaceholder,
+			Name:     models.ErasedPlaceholder,
+			ErasedAt: &erasedAt,
+			ErasedBy: "dpo@example.com",
+		}, nil)
+
+		if assert.NoError(t, h.EraseCustomer(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response models.Customer
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, models.ErasedPlaceholder, response.DocNum)
+			assert.Equal(t, "dpo@example.com", response.ErasedBy)
+		}
+	})
+
+	t.Run("missing requester", func(t *testing.T) {
+		c, _ := newContext(`{}`)
+
+		err := h.EraseCustomer(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateFXRate godoc
+//
+//	@Summary	CreateFXRate
+//	@Schemes	http https
+//	@Tags		fx-rate
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		api.CreateFXRateRequest	true	"CreateFXRateRequest"
+//	@Success	201		{object}	models.FXRate
+//	@Failure	400		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/fx-rates [post]
+func (h *handler) CreateFXRate(c echo.Context) error {
+	req := &api.CreateFXRateRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("CreateFXRate", "request", *req)
+
+	rate, err := h.transactionService.CreateFXRate(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusCreated, rate)
+}
+
+// GetFXRates godoc
+//
+//	@Summary	GetFXRates
+//	@Schemes	http https
+//	@Tags		fx-rate
+//	@Accept		json
+//	@Produce	json
+//	@Param		base_currency	query		string	false	"ISO 4217 currency converted from"
+//	@Param		quote_currency	query		string	false	"ISO 4217 currency converted to"
+//	@Param		at				query		string	false	"Only the rates valid at this time (RFC3339)"
+//	@Param		cursor			query		string	false	"next_cursor of the previous page"
+//	@Param		limit			query		int		false	"Page size (max 100)"
+//	@Success	200				{object}	api.Page[models.FXRate]
+//	@Failure	400				{object}	api.Response
+//	@Failure	500				{object}	api.Response
+//	@Router		/fx-rates [get]
+func (h *handler) GetFXRates(c echo.Context) error {
+	req := &api.ListFXRatesRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("GetFXRates", "req", *req)
+
+	rates, err := h.transactionService.GetFXRates(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, rates)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateFXRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful creation", func(t *testing.T) {
+		reqBody := `{"base_currency":"USD","quote_currency":"BRL","rate":"5.4321","valid_from":"2025-03-01T00:00:00Z"}`
+		req := httptest.NewRequest(http.MethodPost, "/fx-rates", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		validFrom := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+		mockService.EXPECT().CreateFXRate(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *api.CreateFXRateRequest) (*models.FXRate, error) {
+				assert.Equal(t, "5.4321", req.Rate.String())
+				assert.Equal(t, validFrom, *req.ValidFrom)
+				return &models.FXRate{ID: 1, BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: req.Rate, ValidFrom: validFrom}, nil
+			})
+
+		if assert.NoError(t, h.CreateFXRate(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			var response models.FXRate
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.True(t, decimal.RequireFromString("5.4321").Equal(response.Rate))
+			assert.Contains(t, rec.Body.String(), `"rate":"5.4321"`)
+		}
+	})
+
+	t.Run("invalid currency code", func(t *testing.T) {
+		reqBody := `{"base_currency":"US","quote_currency":"BRL","rate":"5.4321"}`
+		req := httptest.NewRequest(http.MethodPost, "/fx-rates", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.CreateFXRate(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestGetFXRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("filters", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/fx-rates?base_currency=USD&quote_currency=BRL&at=2025-03-01T12:00:00Z&limit=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetFXRates(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *api.ListFXRatesRequest) (*api.Page[*models.FXRate], error) {
+				assert.Equal(t, "USD", req.BaseCurrency)
+				assert.Equal(t, "BRL", req.QuoteCurrency)
+				assert.Equal(t, time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC), *req.At)
+				assert.Equal(t, 10, req.Limit)
+				return &api.Page[*models.FXRate]{Items: []*models.FXRate{{ID: 1}}}, nil
+			})
+
+		assert.NoError(t, h.GetFXRates(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -16,8 +16,35 @@ type Handler interface {
 	Health(c echo.Context) error
 	CreateAccount(c echo.Context) error
 	CreateTransaction(c echo.Context) error
+	GetAccounts(c echo.Context) error
 	GetAccountByID(c echo.Context) error
+	UpdateAccount(c echo.Context) error
+	GetAccountBalance(c echo.Context) error
+	CheckBalances(c echo.Context) error
+	GetAccountTransactions(c echo.Context) error
+	CreateCustomer(c echo.Context) error
+	GetCustomerAccounts(c echo.Context) error
+	EraseCustomer(c echo.Context) error
 	GetTransactions(c echo.Context) error
+	GetInstallments(c echo.Context) error
+	ReverseTransaction(c echo.Context) error
+	CaptureTransaction(c echo.Context) error
+	VoidTransaction(c echo.Context) error
+	GetOperationTypes(c echo.Context) error
+	CreateOperationType(c echo.Context) error
+	UpdateOperationType(c echo.Context) error
+	DeactivateOperationType(c echo.Context) error
+	CreateFXRate(c echo.Context) error
+	GetFXRates(c echo.Context) error
+	CreateTransfer(c echo.Context) error
+	GetTransfer(c echo.Context) error
+	GetJournalEntry(c echo.Context) error
+	GetTrialBalance(c echo.Context) error
+	GetAccountStatements(c echo.Context) error
+	GetStatement(c echo.Context) error
+	CreateScheduledTransaction(c echo.Context) error
+	GetScheduledTransaction(c echo.Context) error
+	CancelScheduledTransaction(c echo.Context) error
 }
 
 type handler struct {
@@ -40,6 +67,8 @@ func (h *handler) bindAndValidate(c echo.Context, obj any) error {
 	}
 	slog.DebugContext(ctx, "validating request...")
 	validate := validator.New()
+	validate.RegisterValidation("cpf", isCPF)
+	validate.RegisterValidation("cnpj", isCNPJ)
 	if err := validate.Struct(obj); err != nil {
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
 			var errorMessages []string
@@ -47,6 +76,8 @@ func (h *handler) bindAndValidate(c echo.Context, obj any) error {
 				var msg string
 				switch err.Tag() {
 				// insert cases here when custom validations and tags are added.
+				case "cpf", "cnpj", "cpf|cnpj":
+					msg = fmt.Sprintf("Field '%s:%s' is not a valid %s document number", err.Field(), err.Value(), strings.ReplaceAll(strings.ToUpper(err.Tag()), "|", " or "))
 				default:
 					msg = fmt.Sprintf("Field validation for '%s:%s' failed on the '%s' tag", err.Field(), err.Value(), err.Tag())
 				}
@@ -59,6 +90,32 @@ func (h *handler) bindAndValidate(c echo.Context, obj any) error {
 	return nil
 }
 
+// queryMap collects the name[key]=value query params into a map, nil if there are none
+func queryMap(c echo.Context, name string) map[string]string {
+	var m map[string]string
+	for param, values := range c.QueryParams() {
+		key, ok := strings.CutPrefix(param, name+"[")
+		if !ok || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		if m == nil {
+			m = map[string]string{}
+		}
+		m[strings.TrimSuffix(key, "]")] = values[0]
+	}
+	return m
+}
+
+// isCPF validates a CPF check digits, formatted (123.456.789-09) or not
+func isCPF(fl validator.FieldLevel) bool {
+	return api.IsCPF(fl.Field().String())
+}
+
+// isCNPJ validates a CNPJ check digits, formatted (12.345.678/0001-95) or not
+func isCNPJ(fl validator.FieldLevel) bool {
+	return api.IsCNPJ(fl.Field().String())
+}
+
 func (h *handler) ctx(c echo.Context) context.Context {
 	return c.Request().Context()
 }
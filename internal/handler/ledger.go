@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// GetJournalEntry godoc
+//
+//	@Summary	GetJournalEntry
+//	@Schemes	http https
+//	@Tags		ledger
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Transaction ID"
+//	@Success	200	{object}	models.JournalEntry
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/transactions/{id}/journal-entry [get]
+func (h *handler) GetJournalEntry(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetJournalEntry", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	entry, err := h.transactionService.GetJournalEntry(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// GetTrialBalance godoc
+//
+//	@Summary	GetTrialBalance
+//	@Schemes	http https
+//	@Tags		ledger
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		models.LedgerAccountBalance
+//	@Failure	500	{object}	api.Response
+//	@Router		/ledger/trial-balance [get]
+func (h *handler) GetTrialBalance(c echo.Context) error {
+	slog.Debug("GetTrialBalance")
+
+	lines, err := h.transactionService.GetTrialBalance(c.Request().Context())
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, lines)
+}
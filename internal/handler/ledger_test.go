@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetJournalEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/journal-entry")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().GetJournalEntry(gomock.Any(), int64(1)).Return(&models.JournalEntry{ID: 2, TransactionID: 1, Postings: []*models.Posting{
+			{LedgerAccountID: 1, Amount: money(100), Currency: "BRL"},
+			{LedgerAccountID: 2, Amount: money(-100), Currency: "BRL"},
+		}}, nil)
+
+		if assert.NoError(t, h.GetJournalEntry(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"amount":"-100"`)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/journal-entry")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+
+		err := h.GetJournalEntry(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestGetTrialBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/ledger/trial-balance", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.EXPECT().GetTrialBalance(gomock.Any()).Return([]*models.LedgerAccountBalance{
+		{LedgerAccountID: 1, Code: "customer:1", Kind: models.LedgerCustomer, Currency: "BRL", Debits: money(100), Balance: money(100)},
+	}, nil)
+
+	if assert.NoError(t, h.GetTrialBalance(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"debits":"100"`)
+	}
+}
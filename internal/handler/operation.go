@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// GetOperationTypes godoc
+//
+//	@Summary	GetOperationTypes
+//	@Schemes	http https
+//	@Tags		operation-type
+//	@Accept		json
+//	@Produce	json
+//	@Param		active	query		bool	false	"Only active or inactive types"
+//	@Success	200		{array}		models.OperationType
+//	@Failure	400		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/operation-types [get]
+func (h *handler) GetOperationTypes(c echo.Context) error {
+	req := &api.ListOperationTypesRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("GetOperationTypes", "req", *req)
+
+	operations, err := h.transactionService.GetOperationTypes(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, operations)
+}
+
+// CreateOperationType godoc
+//
+//	@Summary	CreateOperationType
+//	@Schemes	http https
+//	@Tags		operation-type
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		api.OperationTypeRequest	true	"OperationTypeRequest"
+//	@Success	201		{object}	models.OperationType
+//	@Failure	400		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/operation-types [post]
+func (h *handler) CreateOperationType(c echo.Context) error {
+	req := &api.OperationTypeRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("CreateOperationType", "req", *req)
+
+	operation, err := h.transactionService.CreateOperationType(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusCreated, operation)
+}
+
+// UpdateOperationType godoc
+//
+//	@Summary	UpdateOperationType
+//	@Schemes	http https
+//	@Tags		operation-type
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int							true	"Operation type ID"
+//	@Param		request	body		api.OperationTypeRequest	true	"OperationTypeRequest"
+//	@Success	200		{object}	models.OperationType
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/operation-types/{id} [put]
+func (h *handler) UpdateOperationType(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("UpdateOperationType", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.OperationTypeRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	operation, err := h.transactionService.UpdateOperationType(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, operation)
+}
+
+// DeactivateOperationType godoc
+//
+//	@Summary	DeactivateOperationType
+//	@Schemes	http https
+//	@Tags		operation-type
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Operation type ID"
+//	@Success	200	{object}	models.OperationType
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/operation-types/{id}/deactivate [post]
+func (h *handler) DeactivateOperationType(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("DeactivateOperationType", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	operation, err := h.transactionService.DeactivateOperationType(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, operation)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetOperationTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("active filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/operation-types?active=true", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetOperationTypes(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *api.ListOperationTypesRequest) ([]*models.OperationType, error) {
+				if assert.NotNil(t, req.Active) {
+					assert.True(t, *req.Active)
+				}
+				return []*models.OperationType{{ID: 1, Description: "Normal Purchase", EntryType: models.DebitEntry, Active: true}}, nil
+			})
+
+		if assert.NoError(t, h.GetOperationTypes(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response []models.OperationType
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response, 1)
+		}
+	})
+
+	t.Run("no filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/operation-types", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetOperationTypes(gomock.Any(), &api.ListOperationTypesRequest{}).Return(nil, nil)
+
+		assert.NoError(t, h.GetOperationTypes(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestCreateOperationType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful creation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operation-types", strings.NewReader(`{"description":"Cashback","type":"credit"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().CreateOperationType(gomock.Any(), &api.OperationTypeRequest{Description: "Cashback", EntryType: "credit"}).
+			Return(&models.OperationType{ID: 5, Description: "Cashback", EntryType: models.CreditEntry, Active: true}, nil)
+
+		if assert.NoError(t, h.CreateOperationType(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			var response models.OperationType
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(5), response.ID)
+			assert.True(t, response.Active)
+		}
+	})
+
+	t.Run("missing description", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operation-types", strings.NewReader(`{"type":"credit"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.CreateOperationType(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestUpdateOperationType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful update", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/operation-types/3", strings.NewReader(`{"description":"ATM Withdrawal","type":"debit"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/operation-types/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		mockService.EXPECT().UpdateOperationType(gomock.Any(), int64(3), &api.OperationTypeRequest{Description: "ATM Withdrawal", EntryType: "debit"}).
+			Return(&models.OperationType{ID: 3, Description: "ATM Withdrawal", EntryType: models.DebitEntry, Active: true}, nil)
+
+		assert.NoError(t, h.UpdateOperationType(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/operation-types/invalid", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/operation-types/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("invalid")
+
+		err := h.UpdateOperationType(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestDeactivateOperationType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful deactivation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operation-types/3/deactivate", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/operation-types/:id/deactivate")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		mockService.EXPECT().DeactivateOperationType(gomock.Any(), int64(3)).Return(&models.OperationType{ID: 3, EntryType: models.DebitEntry}, nil)
+
+		if assert.NoError(t, h.DeactivateOperationType(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response models.OperationType
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.False(t, response.Active)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operation-types/3/deactivate", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/operation-types/:id/deactivate")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		mockService.EXPECT().DeactivateOperationType(gomock.Any(), int64(3)).Return(nil, api.ServerErr(nil))
+
+		err := h.DeactivateOperationType(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateScheduledTransaction godoc
+//
+//	@Summary	CreateScheduledTransaction
+//	@Schemes	http https
+//	@Tags		scheduled-transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		api.CreateScheduledTransactionRequest	true	"CreateScheduledTransactionRequest"
+//	@Success	201		{object}	models.ScheduledTransaction
+//	@Failure	400		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/scheduled-transactions [post]
+func (h *handler) CreateScheduledTransaction(c echo.Context) error {
+	req := &api.CreateScheduledTransactionRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("CreateScheduledTransaction", "request", *req)
+
+	scheduled, err := h.transactionService.CreateScheduledTransaction(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusCreated, scheduled)
+}
+
+// GetScheduledTransaction godoc
+//
+//	@Summary	GetScheduledTransaction
+//	@Schemes	http https
+//	@Tags		scheduled-transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Scheduled transaction ID"
+//	@Success	200	{object}	models.ScheduledTransaction
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/scheduled-transactions/{id} [get]
+func (h *handler) GetScheduledTransaction(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetScheduledTransaction", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	scheduled, err := h.transactionService.GetScheduledTransaction(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, scheduled)
+}
+
+// CancelScheduledTransaction godoc
+//
+//	@Summary	CancelScheduledTransaction
+//	@Schemes	http https
+//	@Tags		scheduled-transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Scheduled transaction ID"
+//	@Success	200	{object}	models.ScheduledTransaction
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	422	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/scheduled-transactions/{id}/cancel [post]
+func (h *handler) CancelScheduledTransaction(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("CancelScheduledTransaction", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	scheduled, err := h.transactionService.CancelScheduledTransaction(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, scheduled)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful creation", func(t *testing.T) {
+		reqBody := `{"account_id":1,"operation_type_id":1,"amount":"50","frequency":"monthly","start_at":"2030-01-31T10:00:00Z","max_occurrences":12}`
+		req := httptest.NewRequest(http.MethodPost, "/scheduled-transactions", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().CreateScheduledTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *api.CreateScheduledTransactionRequest) (*models.ScheduledTransaction, error) {
+				assert.Equal(t, "monthly", req.Frequency)
+				assert.Equal(t, time.Date(2030, time.January, 31, 10, 0, 0, 0, time.UTC), *req.StartAt)
+				assert.Equal(t, 12, *req.MaxOccurrences)
+				return &models.ScheduledTransaction{ID: 4, AccountID: 1, Frequency: models.FrequencyMonthly, Status: models.ScheduleActive, NextRunAt: req.StartAt}, nil
+			})
+
+		if assert.NoError(t, h.CreateScheduledTransaction(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"next_run_at":"2030-01-31T10:00:00Z"`)
+		}
+	})
+
+	t.Run("missing frequency", func(t *testing.T) {
+		reqBody := `{"account_id":1,"operation_type_id":1,"amount":"50"}`
+		req := httptest.NewRequest(http.MethodPost, "/scheduled-transactions", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.CreateScheduledTransaction(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestCancelScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful cancellation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/scheduled-transactions/:id/cancel")
+		c.SetParamNames("id")
+		c.SetParamValues("4")
+
+		mockService.EXPECT().CancelScheduledTransaction(gomock.Any(), int64(4)).Return(&models.ScheduledTransaction{ID: 4, Status: models.ScheduleCancelled}, nil)
+
+		if assert.NoError(t, h.CancelScheduledTransaction(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"status":"cancelled"`)
+		}
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/scheduled-transactions/:id/cancel")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+
+		err := h.CancelScheduledTransaction(c)
+		he, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+		}
+	})
+}
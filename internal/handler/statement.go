@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// GetAccountStatements godoc
+//
+//	@Summary	GetAccountStatements
+//	@Schemes	http https
+//	@Tags		statement
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int		true	"Account ID"
+//	@Param		cursor	query		string	false	"next_cursor of the previous page"
+//	@Param		limit	query		int		false	"Page size (max 100)"
+//	@Success	200		{object}	api.Page[models.Statement]
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/accounts/{id}/statements [get]
+func (h *handler) GetAccountStatements(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetAccountStatements", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.ListStatementsRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	statements, err := h.transactionService.GetAccountStatements(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, statements)
+}
+
+// GetStatement godoc
+//
+//	@Summary	GetStatement
+//	@Schemes	http https
+//	@Tags		statement
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Statement ID"
+//	@Success	200	{object}	models.Statement
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/statements/{id} [get]
+func (h *handler) GetStatement(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetStatement", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	statement, err := h.transactionService.GetStatement(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, statement)
+}
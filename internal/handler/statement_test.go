@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccountStatements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/?limit=2", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/statements")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().GetAccountStatements(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+			func(_ any, _ int64, req *api.ListStatementsRequest) (*api.Page[*models.Statement], error) {
+				assert.Equal(t, 2, req.Limit)
+				return &api.Page[*models.Statement]{Items: []*models.Statement{{ID: 5, AccountID: 1}}}, nil
+			})
+
+		if assert.NoError(t, h.GetAccountStatements(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"id":5`)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/accounts/:id/statements")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+
+		err := h.GetAccountStatements(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestGetStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/statements/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("5")
+
+		mockService.EXPECT().GetStatement(gomock.Any(), int64(5)).Return(&models.Statement{ID: 5}, nil)
+
+		assert.NoError(t, h.GetStatement(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/statements/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+
+		err := h.GetStatement(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	_ "github.com/akhiltak/pismo-api/internal/storage/models"
 	"github.com/akhiltak/pismo-api/pkg/api"
@@ -42,15 +43,160 @@ func (h *handler) CreateTransaction(c echo.Context) error {
 //	@Tags		transaction
 //	@Accept		json
 //	@Produce	json
-//	@Success	200	{array}		models.Transaction
-//	@Failure	400	{object}	api.Response
-//	@Failure	500	{object}	api.Response
+//	@Param		account_id			query		int		false	"Account ID"
+//	@Param		operation_type_id	query		int		false	"Operation type ID"
+//	@Param		status				query		string	false	"Transaction status"
+//	@Param		event_date_from		query		string	false	"Event date lower bound (RFC3339, inclusive)"
+//	@Param		event_date_to		query		string	false	"Event date upper bound (RFC3339, inclusive)"
+//	@Param		min_amount			query		number	false	"Minimum absolute amount"
+//	@Param		max_amount			query		number	false	"Maximum absolute amount"
+//	@Param		cursor				query		string	false	"next_cursor of the previous page"
+//	@Param		limit				query		int		false	"Page size (max 100)"
+//	@Success	200					{object}	api.Page[models.Transaction]
+//	@Failure	400					{object}	api.Response
+//	@Failure	500					{object}	api.Response
 //	@Router		/transactions [get]
 func (h *handler) GetTransactions(c echo.Context) error {
-	slog.Debug("GetTransactions")
-	transactions, err := h.transactionService.GetTransactions(c.Request().Context())
+	req := &api.ListTransactionsRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("GetTransactions", "req", *req)
+
+	transactions, err := h.transactionService.GetTransactions(c.Request().Context(), req)
 	if err != nil {
 		return api.ServerErr(err)
 	}
 	return c.JSON(http.StatusOK, transactions)
 }
+
+// GetInstallments godoc
+//
+//	@Summary	GetInstallments
+//	@Schemes	http https
+//	@Tags		transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Transaction ID"
+//	@Success	200	{array}		models.Installment
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/transactions/{id}/installments [get]
+func (h *handler) GetInstallments(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetInstallments", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	installments, err := h.transactionService.GetInstallments(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, installments)
+}
+
+// ReverseTransaction godoc
+//
+//	@Summary	ReverseTransaction
+//	@Schemes	http https
+//	@Tags		transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int								true	"Transaction ID"
+//	@Param		request	body		api.ReverseTransactionRequest	false	"ReverseTransactionRequest"
+//	@Success	201		{object}	models.Transaction
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/transactions/{id}/reverse [post]
+func (h *handler) ReverseTransaction(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("ReverseTransaction", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.ReverseTransactionRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	reversal, err := h.transactionService.ReverseTransaction(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusCreated, reversal)
+}
+
+// CaptureTransaction godoc
+//
+//	@Summary	CaptureTransaction
+//	@Schemes	http https
+//	@Tags		transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		int								true	"Transaction ID"
+//	@Param		request	body		api.CaptureTransactionRequest	false	"CaptureTransactionRequest"
+//	@Success	200		{object}	models.Transaction
+//	@Failure	400		{object}	api.Response
+//	@Failure	404		{object}	api.Response
+//	@Failure	409		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/transactions/{id}/capture [post]
+func (h *handler) CaptureTransaction(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("CaptureTransaction", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+	req := &api.CaptureTransactionRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+
+	transaction, err := h.transactionService.CaptureTransaction(c.Request().Context(), id, req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, transaction)
+}
+
+// VoidTransaction godoc
+//
+//	@Summary	VoidTransaction
+//	@Schemes	http https
+//	@Tags		transaction
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Transaction ID"
+//	@Success	200	{object}	models.Transaction
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	409	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/transactions/{id}/void [post]
+func (h *handler) VoidTransaction(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("VoidTransaction", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	transaction, err := h.transactionService.VoidTransaction(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, transaction)
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
 	"github.com/akhiltak/pismo-api/internal/storage/models"
@@ -16,6 +18,11 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// money builds an amount for fixtures, exact for the values used in tests
+func money(f float64) models.Money {
+	return api.Money{Decimal: decimal.NewFromFloat(f)}
+}
+
 func TestCreateTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -36,7 +43,7 @@ func TestCreateTransaction(t *testing.T) {
 			ID:              1,
 			AccountID:       1,
 			OperationTypeID: 1,
-			Amount:          decimal.NewFromFloat(100.50),
+			Amount:          money(100.50),
 			Status:          models.TxnStatusCompleted,
 		}, nil)
 
@@ -76,6 +83,55 @@ func TestCreateTransaction(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, http.StatusBadRequest, he.Code)
 	})
+
+	t.Run("amount as a string", func(t *testing.T) {
+		reqBody := `{"account_id":1,"operation_type_id":1,"amount":"0.1"}`
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *api.CreateTransactionRequest) (*models.Transaction, error) {
+				assert.Equal(t, "0.1", req.Amount.String())
+				return &models.Transaction{ID: 1, Amount: req.Amount}, nil
+			})
+
+		err := h.CreateTransaction(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"amount":"0.1"`)
+	})
+
+	t.Run("amount beyond the money columns", func(t *testing.T) {
+		for _, amount := range []string{`10.12345`, `"1000000000000000"`} {
+			reqBody := `{"account_id":1,"operation_type_id":1,"amount":` + amount + `}`
+			req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.CreateTransaction(c)
+			assert.Error(t, err)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+		}
+	})
+
+	t.Run("invalid currency code", func(t *testing.T) {
+		reqBody := `{"account_id":1,"operation_type_id":1,"amount":100.50,"currency":"REAL"}`
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.CreateTransaction(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
 }
 
 func TestGetTransactions(t *testing.T) {
@@ -93,29 +149,67 @@ func TestGetTransactions(t *testing.T) {
 		c := e.NewContext(req, rec)
 
 		mockTransactions := []*models.Transaction{
-			{ID: 1, AccountID: 1, OperationTypeID: 1, Amount: decimal.NewFromFloat(100.50), Status: models.TxnStatusCompleted},
-			{ID: 2, AccountID: 2, OperationTypeID: 2, Amount: decimal.NewFromFloat(200.75), Status: models.TxnStatusPending},
+			{ID: 1, AccountID: 1, OperationTypeID: 1, Amount: money(100.50), Status: models.TxnStatusCompleted},
+			{ID: 2, AccountID: 2, OperationTypeID: 2, Amount: money(200.75), Status: models.TxnStatusPending},
 		}
 
-		mockService.EXPECT().GetTransactions(gomock.Any()).Return(mockTransactions, nil)
+		mockService.EXPECT().GetTransactions(gomock.Any(), &api.ListTransactionsRequest{}).Return(&api.Page[*models.Transaction]{
+			Items:      mockTransactions,
+			NextCursor: "next",
+		}, nil)
 
 		if assert.NoError(t, h.GetTransactions(c)) {
 			assert.Equal(t, http.StatusOK, rec.Code)
-			var response []*models.Transaction
+			var response api.Page[*models.Transaction]
 			err := json.Unmarshal(rec.Body.Bytes(), &response)
 			assert.NoError(t, err)
-			assert.Len(t, response, 2)
-			assert.Equal(t, int64(1), response[0].ID)
-			assert.Equal(t, int64(2), response[1].ID)
+			assert.Len(t, response.Items, 2)
+			assert.Equal(t, int64(1), response.Items[0].ID)
+			assert.Equal(t, int64(2), response.Items[1].ID)
+			assert.Equal(t, "next", response.NextCursor)
 		}
 	})
 
+	t.Run("filters", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/transactions?account_id=42&operation_type_id=3&status=completed&event_date_from=2025-02-01T00:00:00Z&min_amount=10.5&cursor=abc&limit=20", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().GetTransactions(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *api.ListTransactionsRequest) (*api.Page[*models.Transaction], error) {
+				assert.Equal(t, int64(42), req.AccountID)
+				assert.Equal(t, int64(3), req.OperationTypeID)
+				assert.Equal(t, "completed", req.Status)
+				assert.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), *req.EventDateFrom)
+				assert.Nil(t, req.EventDateTo)
+				assert.True(t, decimal.NewFromFloat(10.5).Equal(req.MinAmount.Decimal))
+				assert.Equal(t, "abc", req.Cursor)
+				assert.Equal(t, 20, req.Limit)
+				return &api.Page[*models.Transaction]{}, nil
+			})
+
+		assert.NoError(t, h.GetTransactions(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/transactions?limit=1000", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.GetTransactions(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
-		mockService.EXPECT().GetTransactions(gomock.Any()).Return(nil, api.ServerErr(nil))
+		mockService.EXPECT().GetTransactions(gomock.Any(), gomock.Any()).Return(nil, api.ServerErr(nil))
 
 		err := h.GetTransactions(c)
 		assert.Error(t, err)
@@ -124,3 +218,200 @@ func TestGetTransactions(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, he.Code)
 	})
 }
+
+func TestGetInstallments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/1/installments", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/installments")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().GetInstallments(gomock.Any(), int64(1)).Return([]*models.Installment{
+			{ID: 1, TransactionID: 1, Number: 1, Amount: money(-50)},
+			{ID: 2, TransactionID: 1, Number: 2, Amount: money(-50)},
+		}, nil)
+
+		if assert.NoError(t, h.GetInstallments(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response []*models.Installment
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Len(t, response, 2)
+			assert.Equal(t, 2, response[1].Number)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/invalid/installments", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/installments")
+		c.SetParamNames("id")
+		c.SetParamValues("invalid")
+
+		err := h.GetInstallments(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestReverseTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful reversal", func(t *testing.T) {
+		reqBody := `{"amount":40}`
+		req := httptest.NewRequest(http.MethodPost, "/transactions/1/reverse", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/reverse")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		originalID := int64(1)
+		mockService.EXPECT().ReverseTransaction(gomock.Any(), int64(1), &api.ReverseTransactionRequest{Amount: api.Money{Decimal: decimal.NewFromInt(40)}}).Return(&models.Transaction{
+			ID:            2,
+			AccountID:     1,
+			Amount:        money(40),
+			Status:        models.TxnStatusCompleted,
+			ReversedTxnID: &originalID,
+		}, nil)
+
+		if assert.NoError(t, h.ReverseTransaction(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			var response models.Transaction
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), response.ID)
+			assert.Equal(t, int64(1), *response.ReversedTxnID)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/invalid/reverse", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/reverse")
+		c.SetParamNames("id")
+		c.SetParamValues("invalid")
+
+		err := h.ReverseTransaction(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestCaptureTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("partial capture", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/1/capture", strings.NewReader(`{"amount":60}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/capture")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().CaptureTransaction(gomock.Any(), int64(1), &api.CaptureTransactionRequest{Amount: api.Money{Decimal: decimal.NewFromInt(60)}}).Return(&models.Transaction{
+			ID:     1,
+			Amount: money(-60),
+			Status: models.TxnStatusCompleted,
+		}, nil)
+
+		if assert.NoError(t, h.CaptureTransaction(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response models.Transaction
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, models.TxnStatusCompleted, response.Status)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/invalid/capture", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/capture")
+		c.SetParamNames("id")
+		c.SetParamValues("invalid")
+
+		err := h.CaptureTransaction(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestVoidTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful void", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/1/void", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/void")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().VoidTransaction(gomock.Any(), int64(1)).Return(&models.Transaction{ID: 1, Status: models.TxnStatusFailed}, nil)
+
+		if assert.NoError(t, h.VoidTransaction(c)) {
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response models.Transaction
+			err := json.Unmarshal(rec.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, models.TxnStatusFailed, response.Status)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/1/void", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transactions/:id/void")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		mockService.EXPECT().VoidTransaction(gomock.Any(), int64(1)).Return(nil, api.ServerErr(nil))
+
+		err := h.VoidTransaction(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+	})
+}
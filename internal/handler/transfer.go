@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	_ "github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateTransfer godoc
+//
+//	@Summary	CreateTransfer
+//	@Schemes	http https
+//	@Tags		transfer
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		api.CreateTransferRequest	true	"CreateTransferRequest"
+//	@Success	201		{object}	models.Transfer
+//	@Failure	400		{object}	api.Response
+//	@Failure	422		{object}	api.Response
+//	@Failure	500		{object}	api.Response
+//	@Router		/transfers [post]
+func (h *handler) CreateTransfer(c echo.Context) error {
+	req := &api.CreateTransferRequest{}
+	if err := h.bindAndValidate(c, req); err != nil {
+		return err
+	}
+	slog.Debug("CreateTransfer", "request", *req)
+
+	transfer, err := h.transactionService.CreateTransfer(c.Request().Context(), req)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusCreated, transfer)
+}
+
+// GetTransfer godoc
+//
+//	@Summary	GetTransfer
+//	@Schemes	http https
+//	@Tags		transfer
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		int	true	"Transfer ID"
+//	@Success	200	{object}	models.Transfer
+//	@Failure	400	{object}	api.Response
+//	@Failure	404	{object}	api.Response
+//	@Failure	500	{object}	api.Response
+//	@Router		/transfers/{id} [get]
+func (h *handler) GetTransfer(c echo.Context) error {
+	idStr := c.Param("id")
+	slog.Debug("GetTransfer", "id", idStr)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return api.BadRequestErr(api.ErrParsingID, err)
+	}
+
+	transfer, err := h.transactionService.GetTransfer(c.Request().Context(), id)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	return c.JSON(http.StatusOK, transfer)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mockService "github.com/akhiltak/pismo-api/internal/service/mock_services"
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful creation", func(t *testing.T) {
+		reqBody := `{"source_account_id":1,"destination_account_id":2,"amount":"40.5","description":"rent"}`
+		req := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockService.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, req *api.CreateTransferRequest) (*models.Transfer, error) {
+				assert.Equal(t, int64(1), req.SourceAccountID)
+				assert.Equal(t, int64(2), req.DestinationAccountID)
+				assert.Equal(t, "40.5", req.Amount.String())
+				return &models.Transfer{ID: 1, SourceAccountID: 1, DestinationAccountID: 2, Amount: req.Amount}, nil
+			})
+
+		if assert.NoError(t, h.CreateTransfer(c)) {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"amount":"40.5"`)
+		}
+	})
+
+	t.Run("missing destination account", func(t *testing.T) {
+		reqBody := `{"source_account_id":1,"amount":"40.5"}`
+		req := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := h.CreateTransfer(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestGetTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockService.NewMockTransactionService(ctrl)
+	h := &handler{transactionService: mockService}
+
+	e := echo.New()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transfers/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("3")
+
+		mockService.EXPECT().GetTransfer(gomock.Any(), int64(3)).Return(&models.Transfer{ID: 3}, nil)
+
+		assert.NoError(t, h.GetTransfer(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/transfers/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("abc")
+
+		err := h.GetTransfer(c)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
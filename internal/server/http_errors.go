@@ -10,6 +10,12 @@ import (
 	"github.com/uptrace/bun/driver/pgdriver"
 )
 
+// constraintMessages explains the violation of a named DB constraint instead of the raw DB message
+var constraintMessages = map[string]string{
+	"customers_document_number_key": api.ErrDuplicateDocument,
+	"fx_rates_no_overlap":           api.ErrFXRateOverlap,
+}
+
 func customHTTPErrorHandler(err error, c echo.Context) {
 	// ctx := c.Request().Context()
 
@@ -19,6 +25,11 @@ func customHTTPErrorHandler(err error, c echo.Context) {
 
 	switch v := err.(type) {
 	case *echo.HTTPError:
+		// handlers wrap service errors as server errors, surface a more specific HTTP error returned by the service
+		var inner *echo.HTTPError
+		if errors.As(v.Internal, &inner) {
+			v, err = inner, inner
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			code = http.StatusNotFound
 			message = "Record not found in DB"
@@ -30,9 +41,15 @@ func customHTTPErrorHandler(err error, c echo.Context) {
 			case "23505": // Unique constraint violation
 				code = http.StatusConflict
 				message = "Duplicate record error: "
+			case "23P01": // Exclusion constraint violation
+				code = http.StatusConflict
+				message = "Conflicting record error: "
 			case "22001": // String data right truncation
 				code = http.StatusBadRequest
 				message = "Data too long for column: "
+			case "22003": // Numeric value out of range
+				code = http.StatusBadRequest
+				message = "Number too large for column: "
 			case "23502": // NOT NULL violation
 				code = http.StatusBadRequest
 				message = "Missing required field: "
@@ -40,7 +57,11 @@ func customHTTPErrorHandler(err error, c echo.Context) {
 				code = http.StatusInternalServerError
 				message = "Database error: "
 			}
-			message += pgErr.Field('M')
+			if msg, ok := constraintMessages[pgErr.Field('n')]; ok {
+				message = msg
+			} else {
+				message += pgErr.Field('M')
+			}
 		} else {
 			code = v.Code
 			message = v.Message.(string)
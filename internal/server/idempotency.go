@@ -0,0 +1,141 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyStorageTimeout = 5 * time.Second
+)
+
+// idempotency makes the wrapped handler safe to retry when the client sends an Idempotency-Key header
+//   - the first request with a key is processed and its response is stored along with a hash of the request
+//   - a repeat with the same key and request replays the stored response instead of running the handler again
+//   - a repeat with the same key but a different request is rejected with 422
+//   - a repeat while the first request is still running is rejected with 409, unless the first request has held the
+//     key for longer than lease without a response (e.g. the server stopped while processing it): it is then taken over
+//
+// Server errors (5xx) are not stored so that the request can be retried, keys expire after ttl.
+// The lease has to outlast the slowest request, a request taken over while still running would be processed twice.
+func idempotency(keys repo.IdempotencyKey, ttl, lease time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return api.BadRequestErr(api.ErrIdempotencyKeyTooLong, nil)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return api.BadRequestErr("invalid request, please verify", err)
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			// the key must outlive the request, the client may well have timed out already
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request().Context()), idempotencyStorageTimeout)
+			defer cancel()
+
+			// the DB keeps microseconds, the reservation is told apart from the one of a retry by its time
+			now := time.Now().UTC().Truncate(time.Microsecond)
+			record := &models.IdempotencyKey{
+				Key:         key,
+				RequestHash: requestHash(c.Request(), body),
+				ReservedAt:  now,
+				ExpiresAt:   now.Add(ttl),
+			}
+			reserved, err := keys.Reserve(ctx, record, lease)
+			if err != nil {
+				return api.ServerErr(err)
+			}
+			if !reserved {
+				return replay(ctx, c, keys, record)
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			if err := next(c); err != nil {
+				// write the error response now so that it can be stored
+				c.Error(err)
+			}
+
+			if c.Response().Status >= http.StatusInternalServerError {
+				if err := keys.Release(ctx, record); err != nil {
+					slog.Error("unable to release idempotency key", "key", key, "error", err)
+				}
+				return nil
+			}
+			record.ResponseCode = c.Response().Status
+			record.ResponseBody = recorder.body.Bytes()
+			if err := keys.SaveResponse(ctx, record); err != nil {
+				slog.Error("unable to store idempotent response", "key", key, "error", err)
+			}
+			return nil
+		}
+	}
+}
+
+// purgeIdempotencyKeys deletes the expired keys, which would otherwise only be overwritten when reused
+func purgeIdempotencyKeys(keys repo.IdempotencyKey) func(context.Context) error {
+	return func(ctx context.Context) error {
+		purged, err := keys.Purge(ctx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		slog.Debug("purged expired idempotency keys", "count", purged)
+		return nil
+	}
+}
+
+// replay writes the stored response of the request that reserved the key
+func replay(ctx context.Context, c echo.Context, keys repo.IdempotencyKey, record *models.IdempotencyKey) error {
+	stored, err := keys.GetByKey(ctx, record.Key)
+	if err != nil {
+		return api.ServerErr(err)
+	}
+	if stored.RequestHash != record.RequestHash {
+		return api.UnprocessableEntityErr(api.ErrIdempotencyKeyReused, nil)
+	}
+	if stored.InProgress() {
+		return api.ConflictErr(api.ErrIdempotencyKeyInProgress, nil)
+	}
+	slog.Debug("replaying idempotent response", "key", record.Key, "code", stored.ResponseCode)
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	return c.JSONBlob(stored.ResponseCode, stored.ResponseBody)
+}
+
+// requestHash fingerprints a request, the same key must not be used for a different endpoint or body
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while it is written to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeys := mockRepo.NewMockIdempotencyKey(ctrl)
+	middleware := idempotency(mockKeys, time.Hour, time.Minute)
+
+	e := echo.New()
+	e.HTTPErrorHandler = customHTTPErrorHandler
+
+	calls := 0
+	created := middleware(func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"id": 1})
+	})
+	failed := middleware(func(c echo.Context) error {
+		calls++
+		return api.ServerErr(nil)
+	})
+
+	newContext := func(key, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		return e.NewContext(req, rec), rec
+	}
+
+	t.Run("without key", func(t *testing.T) {
+		calls = 0
+		c, rec := newContext("", `{"amount":1}`)
+
+		assert.NoError(t, created(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("first request stores the response", func(t *testing.T) {
+		calls = 0
+		c, rec := newContext("key-1", `{"amount":1}`)
+
+		mockKeys.EXPECT().Reserve(gomock.Any(), gomock.Any(), time.Minute).DoAndReturn(
+			func(_ context.Context, record *models.IdempotencyKey, _ time.Duration) (bool, error) {
+				assert.WithinDuration(t, time.Now(), record.ReservedAt, time.Minute)
+				assert.Equal(t, record.ReservedAt.Add(time.Hour), record.ExpiresAt)
+				return true, nil
+			})
+		mockKeys.EXPECT().SaveResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, record *models.IdempotencyKey) error {
+				assert.Equal(t, "key-1", record.Key)
+				assert.Equal(t, http.StatusCreated, record.ResponseCode)
+				assert.JSONEq(t, `{"id":1}`, string(record.ResponseBody))
+				return nil
+			})
+
+		assert.NoError(t, created(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("repeat replays the stored response", func(t *testing.T) {
+		calls = 0
+		c, rec := newContext("key-1", `{"amount":1}`)
+
+		mockKeys.EXPECT().Reserve(gomock.Any(), gomock.Any(), time.Minute).Return(false, nil)
+		mockKeys.EXPECT().GetByKey(gomock.Any(), "key-1").Return(&models.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  requestHash(c.Request(), []byte(`{"amount":1}`)),
+			ResponseCode: http.StatusCreated,
+			ResponseBody: []byte(`{"id":1}`),
+		}, nil)
+
+		assert.NoError(t, created(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":1}`, rec.Body.String())
+		assert.Equal(t, "true", rec.Header().Get(HeaderIdempotentReplayed))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("repeat with a different body", func(t *testing.T) {
+		calls = 0
+		c, _ := newContext("key-1", `{"amount":2}`)
+
+		mockKeys.EXPECT().Reserve(gomock.Any(), gomock.Any(), time.Minute).Return(false, nil)
+		mockKeys.EXPECT().GetByKey(gomock.Any(), "key-1").Return(&models.IdempotencyKey{
+			Key:          "key-1",
+			RequestHash:  requestHash(c.Request(), []byte(`{"amount":1}`)),
+			ResponseCode: http.StatusCreated,
+		}, nil)
+
+		err := created(c)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("repeat while the first request is in progress", func(t *testing.T) {
+		calls = 0
+		c, _ := newContext("key-1", `{"amount":1}`)
+
+		mockKeys.EXPECT().Reserve(gomock.Any(), gomock.Any(), time.Minute).Return(false, nil)
+		mockKeys.EXPECT().GetByKey(gomock.Any(), "key-1").Return(&models.IdempotencyKey{
+			Key:         "key-1",
+			RequestHash: requestHash(c.Request(), []byte(`{"amount":1}`)),
+		}, nil)
+
+		err := created(c)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("retry takes over a key whose lease is over", func(t *testing.T) {
+		calls = 0
+		c, rec := newContext("key-3", `{"amount":1}`)
+
+		// the request that reserved the key never stored a response, Reserve takes the key over once the lease is over
+		mockKeys.EXPECT().Reserve(gomock.Any(), gomock.Any(), time.Minute).Return(true, nil)
+		mockKeys.EXPECT().SaveResponse(gomock.Any(), gomock.Any()).Return(nil)
+
+		assert.NoError(t, created(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("server errors release the key", func(t *testing.T) {
+		calls = 0
+		c, rec := newContext("key-2", `{"amount":1}`)
+
+		mockKeys.EXPECT().Reserve(gomock.Any(), gomock.Any(), time.Minute).Return(true, nil)
+		mockKeys.EXPECT().Release(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, record *models.IdempotencyKey) error {
+				assert.Equal(t, "key-2", record.Key)
+				assert.False(t, record.ReservedAt.IsZero())
+				return nil
+			})
+
+		assert.NoError(t, failed(c))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestPurgeIdempotencyKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeys := mockRepo.NewMockIdempotencyKey(ctrl)
+	purge := purgeIdempotencyKeys(mockKeys)
+
+	t.Run("deletes the keys expired by now", func(t *testing.T) {
+		before := time.Now().UTC()
+		mockKeys.EXPECT().Purge(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, now time.Time) (int64, error) {
+				assert.False(t, now.Before(before))
+				return 3, nil
+			})
+
+		assert.NoError(t, purge(context.Background()))
+	})
+
+	t.Run("failure is reported", func(t *testing.T) {
+		mockKeys.EXPECT().Purge(gomock.Any(), gomock.Any()).Return(int64(0), assert.AnError)
+
+		assert.ErrorIs(t, purge(context.Background()), assert.AnError)
+	})
+}
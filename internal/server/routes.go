@@ -12,12 +12,63 @@ func (s *Server) initRoutes(h handler.Handler) {
 
 	account := s.router.Group("/accounts")
 	{
-		account.POST("", h.CreateAccount)
+		account.POST("", h.CreateAccount, s.idempotency)
+		account.GET("", h.GetAccounts)
 		account.GET("/:id", h.GetAccountByID)
+		account.PATCH("/:id", h.UpdateAccount)
+		account.GET("/:id/balance", h.GetAccountBalance)
+		account.GET("/:id/transactions", h.GetAccountTransactions)
+		account.GET("/:id/statements", h.GetAccountStatements)
+	}
+	customer := s.router.Group("/customers")
+	{
+		customer.POST("", h.CreateCustomer, s.idempotency)
+		customer.GET("/:id/accounts", h.GetCustomerAccounts)
 	}
 	transaction := s.router.Group("/transactions")
 	{
-		transaction.POST("", h.CreateTransaction)
+		transaction.POST("", h.CreateTransaction, s.idempotency)
 		transaction.GET("", h.GetTransactions)
+		transaction.GET("/:id/installments", h.GetInstallments)
+		transaction.POST("/:id/reverse", h.ReverseTransaction)
+		transaction.POST("/:id/capture", h.CaptureTransaction)
+		transaction.POST("/:id/void", h.VoidTransaction)
+		transaction.GET("/:id/journal-entry", h.GetJournalEntry)
+	}
+	transfer := s.router.Group("/transfers")
+	{
+		transfer.POST("", h.CreateTransfer, s.idempotency)
+		transfer.GET("/:id", h.GetTransfer)
+	}
+	scheduledTransaction := s.router.Group("/scheduled-transactions")
+	{
+		scheduledTransaction.POST("", h.CreateScheduledTransaction, s.idempotency)
+		scheduledTransaction.GET("/:id", h.GetScheduledTransaction)
+		scheduledTransaction.POST("/:id/cancel", h.CancelScheduledTransaction)
+	}
+	statement := s.router.Group("/statements")
+	{
+		statement.GET("/:id", h.GetStatement)
+	}
+	ledger := s.router.Group("/ledger")
+	{
+		ledger.GET("/trial-balance", h.GetTrialBalance)
+	}
+	admin := s.router.Group("/admin")
+	{
+		admin.POST("/customers/:id/erase", h.EraseCustomer)
+		admin.POST("/balances/check", h.CheckBalances)
+	}
+	operationType := s.router.Group("/operation-types")
+	{
+		operationType.GET("", h.GetOperationTypes)
+		operationType.POST("", h.CreateOperationType)
+		operationType.PUT("/:id", h.UpdateOperationType)
+		operationType.POST("/:id/deactivate", h.DeactivateOperationType)
+	}
+	fxRate := s.router.Group("/fx-rates")
+	{
+		fxRate.GET("", h.GetFXRates)
+		fxRate.POST("", h.CreateFXRate)
 	}
 }
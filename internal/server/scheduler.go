@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/service"
+)
+
+// job is a background task the server runs every interval next to the REST API, an interval of 0 disables it
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(context.Context) error
+}
+
+// loop runs the job every interval until ctx is cancelled, a failed run is logged and retried on the next tick
+func (j job) loop(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.run(ctx); err != nil {
+				slog.Error("background job failed", "job", j.name, "error", err)
+			}
+		}
+	}
+}
+
+// bookScheduledTransactions books the due scheduled transactions, skipping the occurrences missed by more than maxLateness
+// Several servers can run it at the same time, an occurrence is only ever booked once (see RunScheduledTransactions)
+func bookScheduledTransactions(srv service.TransactionService, maxLateness time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		executions, err := srv.RunScheduledTransactions(ctx, time.Now().UTC(), maxLateness)
+		if err != nil {
+			return err
+		}
+		if len(executions) > 0 {
+			slog.Info("scheduled transactions booked", "executions", len(executions))
+		}
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"log/slog"
+	"sync"
 
 	"github.com/akhiltak/pismo-api/config"
 	"github.com/akhiltak/pismo-api/db/connection/bunorm"
@@ -12,27 +13,24 @@ import (
 	"github.com/akhiltak/pismo-api/internal/storage/repo"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/uptrace/bun"
 )
 
 type Server struct {
-	router *echo.Echo
+	router      *echo.Echo
+	idempotency echo.MiddlewareFunc
+	jobs        []job              // background jobs run next to the REST API
+	stopJobs    context.CancelFunc // cancels the context of the running jobs
+	jobsDone    sync.WaitGroup     // running jobs
 }
 
 func New(ctx context.Context, cfg *config.Config) *Server {
 
-	// auto migrate database
-	dbmate.Migrate(ctx, cfg.PostgresDNS, cfg.Debug)
-
-	// connect to database
-	db := bunorm.Connect(ctx, cfg.PostgresDNS, true)
-
-	// initialize repositories
-	accountRepo := repo.NewAccountRepo(db)
-	transactionRepo := repo.NewTransactionRepo(db)
-	operationRepo := repo.NewOperationRepo(db)
+	db := connect(ctx, cfg)
+	idempotencyKeyRepo := repo.NewIdempotencyKeyRepo(db)
 
 	// initialize services
-	transactionService := service.NewTransactionService(accountRepo, transactionRepo, operationRepo)
+	transactionService := newTransactionService(db)
 
 	// initialize handlers
 	handler := handler.New(transactionService)
@@ -60,18 +58,72 @@ func New(ctx context.Context, cfg *config.Config) *Server {
 			echo.HeaderAccessControlAllowMethods,
 			echo.HeaderAccessControlAllowOrigin,
 			echo.HeaderAccessControlAllowCredentials,
+			HeaderIdempotencyKey,
 		},
 	}))
 	router.HTTPErrorHandler = customHTTPErrorHandler
 
-	srv := &Server{router: router}
+	srv := &Server{
+		router:      router,
+		idempotency: idempotency(idempotencyKeyRepo, cfg.IdempotencyKeyTTL, cfg.IdempotencyKeyLease),
+		jobs: []job{
+			{name: "purge idempotency keys", interval: cfg.IdempotencyKeyPurgeInterval, run: purgeIdempotencyKeys(idempotencyKeyRepo)},
+			{name: "book scheduled transactions", interval: cfg.SchedulerInterval, run: bookScheduledTransactions(transactionService, cfg.SchedulerMaxLateness)},
+		},
+	}
 	srv.initRoutes(handler)
 
 	return srv
 }
 
+// NewTransactionService connects to the database and builds the service without the REST server,
+// for the commands scheduled next to it
+func NewTransactionService(ctx context.Context, cfg *config.Config) service.TransactionService {
+	return newTransactionService(connect(ctx, cfg))
+}
+
+// connect migrates the database and connects to it
+func connect(ctx context.Context, cfg *config.Config) *bun.DB {
+	// auto migrate database
+	dbmate.Migrate(ctx, cfg.PostgresDNS, cfg.Debug)
+
+	// connect to database
+	return bunorm.Connect(ctx, cfg.PostgresDNS, true)
+}
+
+// newTransactionService initializes the repositories and the service on top of them
+func newTransactionService(db bun.IDB) service.TransactionService {
+	return service.NewTransactionService(service.Repos{
+		Account:              repo.NewAccountRepo(db),
+		Transaction:          repo.NewTransactionRepo(db),
+		Operation:            repo.NewOperationRepo(db),
+		Installment:          repo.NewInstallmentRepo(db),
+		Customer:             repo.NewCustomerRepo(db),
+		FXRate:               repo.NewFXRateRepo(db),
+		Transfer:             repo.NewTransferRepo(db),
+		Ledger:               repo.NewLedgerRepo(db),
+		Statement:            repo.NewStatementRepo(db),
+		Accrual:              repo.NewAccrualRepo(db),
+		ScheduledTransaction: repo.NewScheduledTransactionRepo(db),
+		IdempotencyKey:       repo.NewIdempotencyKeyRepo(db),
+	})
+}
+
 func (s *Server) Run(addr string) error {
 
+	// Start the background jobs, unless disabled
+	var ctx context.Context
+	ctx, s.stopJobs = context.WithCancel(context.Background())
+	for _, j := range s.jobs {
+		if j.interval > 0 {
+			s.jobsDone.Add(1)
+			go func() {
+				defer s.jobsDone.Done()
+				j.loop(ctx)
+			}()
+		}
+	}
+
 	// Start server
 	go func() {
 		if err := s.router.Start(addr); err != nil {
@@ -83,5 +135,18 @@ func (s *Server) Run(addr string) error {
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
+	// let the runs in progress end, as long as ctx allows
+	if s.stopJobs != nil {
+		s.stopJobs()
+		done := make(chan struct{})
+		go func() {
+			s.jobsDone.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+	}
 	return s.router.Shutdown(ctx)
 }
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/uptrace/bun"
+)
+
+// GetAccounts fetches one page of accounts along with their customer, optionally only the ones of a document number
+// and/or the ones whose metadata contains all the given key/values
+// The document number is normalized the same way it is when the customer is created
+func (s *txnSrv) GetAccounts(ctx context.Context, req *api.ListAccountsRequest) (*api.Page[*models.Account], error) {
+	cursor, err := repo.DecodeCursor(req.Cursor, "")
+	if err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidCursor, err)
+	}
+	q := repo.NewListQuery().Relation("Customer").Page(cursor, req.Limit)
+	if req.DocNum != "" {
+		q.Where("?TableAlias.customer_id IN (SELECT id FROM customers WHERE document_number = ?)", api.NormalizeDocument(req.DocNum))
+	}
+
+	if len(req.Metadata) > 0 {
+		q.Contains("metadata", req.Metadata)
+	}
+
+	accounts, next, err := s.accountRepo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Page[*models.Account]{Items: accounts, NextCursor: next.Encode()}, nil
+}
+
+// UpdateAccount changes the status and/or the metadata of an account, in one DB transaction
+// The account row is locked so that no transaction can be booked while the status changes
+// The metadata, when given, replaces the whole metadata of the account
+func (s *txnSrv) UpdateAccount(ctx context.Context, id int64, req *api.UpdateAccountRequest) (*models.Account, error) {
+	if req.Status == "" && req.Metadata == nil {
+		return nil, api.BadRequestErr(api.ErrNothingToUpdate, nil)
+	}
+	status := models.AccountStatus(req.Status)
+	if req.Status != "" {
+		if err := status.Validate(); err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidAccountStatus, err)
+		}
+	}
+
+	var account *models.Account
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var err error
+		if account, err = s.accountRepo.GetByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if req.Status != "" {
+			if err := s.changeStatus(ctx, account, status, req.Reason); err != nil {
+				return err
+			}
+		}
+		if req.Metadata != nil {
+			account.Metadata = req.Metadata
+			return s.accountRepo.UpdateMetadata(ctx, account)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// changeStatus moves a locked account between active, blocked and closed, recording the reason and time of the change
+// An account can only be closed once its balance is settled and it has no pending authorization left, which could
+// neither be captured nor voided afterwards
+func (s *txnSrv) changeStatus(ctx context.Context, account *models.Account, status models.AccountStatus, reason string) error {
+	if !canTransitionAccount(account.Status, status) {
+		return api.ConflictErr(api.ErrAccountStatusChange, nil)
+	}
+	if status == models.AccountStatusClosed {
+		balance, err := s.transactionRepo.GetBalance(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if !balance.TotalCredits.Add(balance.TotalDebits).IsZero() {
+			return api.UnprocessableEntityErr(api.ErrAccountBalanceNotZero, nil)
+		}
+		pending, err := s.transactionRepo.HasPending(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return api.UnprocessableEntityErr(api.ErrAccountPending, nil)
+		}
+	}
+	slog.Debug("UpdateAccount", "account", account.ID, "from", account.Status, "to", status)
+
+	now := time.Now().UTC()
+	account.Status = status
+	account.StatusReason = reason
+	account.StatusChangedAt = &now
+	return s.accountRepo.UpdateStatus(ctx, account)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo})
+
+	t.Run("by formatted document number", func(t *testing.T) {
+		expected := []*models.Account{{ID: 1, Customer: &models.Customer{DocNum: "52998224725"}}}
+
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(expected, nil, nil)
+
+		page, err := service.GetAccounts(context.Background(), &api.ListAccountsRequest{DocNum: "529.982.247-25"})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page.Items)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("by metadata", func(t *testing.T) {
+		expected := []*models.Account{{ID: 2, Metadata: map[string]string{"crm_id": "42", "product": "gold"}}}
+
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(expected, nil, nil)
+
+		page, err := service.GetAccounts(context.Background(), &api.ListAccountsRequest{Metadata: map[string]string{"crm_id": "42"}})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page.Items)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*models.Account{{ID: 3}}, &repo.Cursor{ID: 3}, nil)
+
+		page, err := service.GetAccounts(context.Background(), &api.ListAccountsRequest{Cursor: (&repo.Cursor{ID: 2}).Encode(), Limit: 1})
+		assert.NoError(t, err)
+		next, err := repo.DecodeCursor(page.NextCursor, "")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), next.ID)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		page, err := service.GetAccounts(context.Background(), &api.ListAccountsRequest{Cursor: "not a cursor"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, page)
+	})
+}
+
+func TestUpdateAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo})
+
+	lockAccount := func(status models.AccountStatus) *models.Account {
+		account := &models.Account{ID: 1, Status: status}
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		return account
+	}
+
+	t.Run("block an active account", func(t *testing.T) {
+		account := lockAccount(models.AccountStatusActive)
+		mockAccountRepo.EXPECT().UpdateStatus(gomock.Any(), account).Return(nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "blocked", Reason: "card stolen"})
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountStatusBlocked, updated.Status)
+		assert.Equal(t, "card stolen", updated.StatusReason)
+		assert.NotNil(t, updated.StatusChangedAt)
+	})
+
+	t.Run("reactivate a blocked account", func(t *testing.T) {
+		account := lockAccount(models.AccountStatusBlocked)
+		mockAccountRepo.EXPECT().UpdateStatus(gomock.Any(), account).Return(nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "active", Reason: "card replaced"})
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountStatusActive, updated.Status)
+	})
+
+	t.Run("close a settled account", func(t *testing.T) {
+		account := lockAccount(models.AccountStatusBlocked)
+		mockTransactionRepo.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(&models.Balance{
+			TotalCredits: decimal.NewFromFloat(100),
+			TotalDebits:  decimal.NewFromFloat(-100),
+		}, nil)
+		mockTransactionRepo.EXPECT().HasPending(gomock.Any(), int64(1)).Return(false, nil)
+		mockAccountRepo.EXPECT().UpdateStatus(gomock.Any(), account).Return(nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "closed", Reason: "customer request"})
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountStatusClosed, updated.Status)
+	})
+
+	t.Run("close with a non-zero balance", func(t *testing.T) {
+		lockAccount(models.AccountStatusActive)
+		mockTransactionRepo.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(&models.Balance{
+			TotalCredits: decimal.NewFromFloat(100),
+			TotalDebits:  decimal.NewFromFloat(-80),
+		}, nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "closed", Reason: "customer request"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("close with pending authorizations", func(t *testing.T) {
+		lockAccount(models.AccountStatusActive)
+		mockTransactionRepo.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(&models.Balance{}, nil)
+		mockTransactionRepo.EXPECT().HasPending(gomock.Any(), int64(1)).Return(true, nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "closed", Reason: "customer request"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrAccountPending, he.Message)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("closed is final", func(t *testing.T) {
+		lockAccount(models.AccountStatusClosed)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "active", Reason: "oops"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("replace metadata", func(t *testing.T) {
+		account := lockAccount(models.AccountStatusActive)
+		account.Metadata = map[string]string{"crm_id": "42", "product": "gold"}
+		mockAccountRepo.EXPECT().UpdateMetadata(gomock.Any(), account).Return(nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Metadata: map[string]string{"crm_id": "43"}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"crm_id": "43"}, updated.Metadata)
+		assert.Equal(t, models.AccountStatusActive, updated.Status)
+	})
+
+	t.Run("status and metadata", func(t *testing.T) {
+		account := lockAccount(models.AccountStatusActive)
+		mockAccountRepo.EXPECT().UpdateStatus(gomock.Any(), account).Return(nil)
+		mockAccountRepo.EXPECT().UpdateMetadata(gomock.Any(), account).Return(nil)
+
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "blocked", Reason: "card stolen", Metadata: map[string]string{}})
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountStatusBlocked, updated.Status)
+		assert.Empty(t, updated.Metadata)
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		updated, err := service.UpdateAccount(context.Background(), 1, &api.UpdateAccountRequest{Status: "frozen", Reason: "oops"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(2)).Return(nil, sql.ErrNoRows)
+
+		updated, err := service.UpdateAccount(context.Background(), 2, &api.UpdateAccountRequest{Status: "blocked", Reason: "fraud"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, updated)
+	})
+}
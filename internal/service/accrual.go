@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// accrualBatchSize is the number of accounts read per page while accruing interest and late fees
+const accrualBatchSize = 100
+
+// Accrue charges the interest and late fees of a day (UTC) that has already ended, account by account
+// Running it again for the same day, or concurrently, charges nothing more: each charge is recorded as an accrual of
+// its account, kind and day, checked with the account row locked and unique in the DB
+// A day missed by the daily job has to be accrued on its own, charges are computed from the balances at run time
+func (s *txnSrv) Accrue(ctx context.Context, day time.Time) ([]*models.Accrual, error) {
+	day = day.UTC().Truncate(24 * time.Hour)
+	if day.AddDate(0, 0, 1).After(time.Now().UTC()) {
+		return nil, api.BadRequestErr(api.ErrAccrualDayNotEnded, nil)
+	}
+	operations := map[models.AccrualKind]*models.OperationType{}
+	for _, kind := range []models.AccrualKind{models.AccrualInterest, models.AccrualLateFee} {
+		operation, err := s.operationRepo.GetByCode(ctx, kind.OperationCode())
+		if err != nil {
+			return nil, err
+		}
+		operations[kind] = operation
+	}
+
+	var accruals []*models.Accrual
+	var cursor *repo.Cursor
+	for {
+		accounts, next, err := s.accountRepo.List(ctx, repo.NewListQuery().Page(cursor, accrualBatchSize))
+		if err != nil {
+			return accruals, err
+		}
+		for _, account := range accounts {
+			// closing an account requires a settled balance, there is nothing left to charge
+			if account.Status == models.AccountStatusClosed {
+				continue
+			}
+			charged, err := s.accrueAccount(ctx, account.ID, day, operations)
+			if err != nil {
+				return accruals, err
+			}
+			accruals = append(accruals, charged...)
+		}
+		if next == nil {
+			return accruals, nil
+		}
+		cursor = next
+	}
+}
+
+// accrueAccount charges the interest and late fee of an account for a day, in one DB transaction with the account row locked
+//   - interest: the daily share of the monthly rate of their operation type on what is still owed of the debits frozen
+//     into a statement due before the day, rounded once to the decimals of the currency
+//   - late fee: when a statement is due on the day and the credits posted since it was closed do not cover its minimum
+//     payment, LateFeeRate of the part left unpaid
+func (s *txnSrv) accrueAccount(ctx context.Context, accountID int64, day time.Time, operations map[models.AccrualKind]*models.OperationType) ([]*models.Accrual, error) {
+	var accruals []*models.Accrual
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		account, err := s.accountRepo.GetByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		accrued, err := s.accrualRepo.Exists(ctx, account.ID, models.AccrualInterest, day)
+		if err != nil {
+			return err
+		}
+		if !accrued {
+			debits, err := s.transactionRepo.GetOverdueDebits(ctx, account.ID, day)
+			if err != nil {
+				return err
+			}
+			interest := decimal.Zero
+			for _, debit := range debits {
+				interest = interest.Add(debit.OperationType.DailyInterest(debit.Balance.Decimal))
+			}
+			if interest = interest.Round(account.Currency.Exponent()); interest.IsPositive() {
+				accrual, err := s.chargeFee(ctx, account, operations[models.AccrualInterest], &models.Accrual{Kind: models.AccrualInterest, AccrualDate: day, Amount: money.New(interest)})
+				if err != nil {
+					return err
+				}
+				accruals = append(accruals, accrual)
+			}
+		}
+
+		statement, err := s.statementRepo.GetDueOn(ctx, account.ID, day)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !statement.MinimumPayment.IsPositive() {
+			return nil
+		}
+		if accrued, err = s.accrualRepo.Exists(ctx, account.ID, models.AccrualLateFee, day); err != nil {
+			return err
+		}
+		if accrued {
+			return nil
+		}
+		paid, err := s.transactionRepo.GetPaidAmount(ctx, account.ID, statement.PeriodEnd, day.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		unpaid := statement.MinimumPayment.Sub(paid)
+		if fee := unpaid.Mul(models.LateFeeRate).Round(account.Currency.Exponent()); fee.IsPositive() {
+			accrual, err := s.chargeFee(ctx, account, operations[models.AccrualLateFee], &models.Accrual{Kind: models.AccrualLateFee, AccrualDate: day, Amount: money.New(fee), StatementID: &statement.ID})
+			if err != nil {
+				return err
+			}
+			accruals = append(accruals, accrual)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return accruals, nil
+}
+
+// chargeFee books an accrual on a locked account: a debit of the operation type of its kind, booked in the ledger against fees
+// Unlike any other debit it is charged whatever the status and the available credit limit of the account, what the
+// limit left does not cover is added to the over limit amount of the account
+func (s *txnSrv) chargeFee(ctx context.Context, account *models.Account, operation *models.OperationType, accrual *models.Accrual) (*models.Accrual, error) {
+	if account.AvailableCreditLimit != nil {
+		covered := decimal.Min(accrual.Amount.Decimal, account.AvailableCreditLimit.Decimal)
+		available := money.New(account.AvailableCreditLimit.Sub(covered))
+		account.AvailableCreditLimit = &available
+		account.OverLimit = money.New(account.OverLimit.Add(accrual.Amount.Sub(covered)))
+		if err := s.accountRepo.UpdateCreditLimit(ctx, account); err != nil {
+			return nil, err
+		}
+	}
+	txn, err := s.transactionRepo.Create(ctx, &models.Transaction{
+		AccountID:       account.ID,
+		OperationTypeID: operation.ID,
+		OperationType:   operation,
+		Currency:        account.Currency,
+		Status:          models.TxnStatusCompleted,
+		Amount:          money.New(accrual.Amount.Neg()),
+		Balance:         money.New(accrual.Amount.Neg()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.journal(ctx, txn); err != nil {
+		return nil, err
+	}
+	slog.Debug("chargeFee", "account", account.ID, "kind", accrual.Kind, "day", accrual.AccrualDate, "amount", accrual.Amount)
+
+	accrual.AccountID = account.ID
+	accrual.TransactionID = txn.ID
+	return s.accrualRepo.Create(ctx, accrual)
+}
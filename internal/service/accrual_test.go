@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccrue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	mockStatementRepo := mockRepo.NewMockStatement(ctrl)
+	mockAccrualRepo := mockRepo.NewMockAccrual(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Operation: mockOperationRepo, Ledger: acceptJournal(ctrl), Statement: mockStatementRepo, Accrual: mockAccrualRepo})
+
+	interest := &models.OperationType{ID: 7, EntryType: models.DebitEntry, Code: models.OpCodeInterest}
+	lateFee := &models.OperationType{ID: 8, EntryType: models.DebitEntry, Code: models.OpCodeLateFee}
+	mockOperationRepo.EXPECT().GetByCode(gomock.Any(), models.OpCodeInterest).Return(interest, nil).AnyTimes()
+	mockOperationRepo.EXPECT().GetByCode(gomock.Any(), models.OpCodeLateFee).Return(lateFee, nil).AnyTimes()
+
+	day := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+	purchases := &models.OperationType{ID: 1, EntryType: models.DebitEntry, InterestRate: decimalPtr("0.12")}
+	withdrawals := &models.OperationType{ID: 3, EntryType: models.DebitEntry, InterestRate: decimalPtr("0.15")}
+
+	// lockAccount expects the account to be listed and its row locked
+	lockAccount := func(account *models.Account) {
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*models.Account{account}, nil, nil)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), account.ID).Return(account, nil)
+	}
+	// expectCharge expects a debit of the operation type to be booked and recorded as an accrual
+	expectCharge := func(operation *models.OperationType, amount string) {
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				assert.Equal(t, operation.ID, txn.OperationTypeID)
+				assert.Equal(t, "-"+amount, txn.Amount.String())
+				assert.Equal(t, txn.Amount, txn.Balance)
+				txn.ID = operation.ID * 10
+				return txn, nil
+			})
+		mockAccrualRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, accrual *models.Accrual) (*models.Accrual, error) {
+				return accrual, nil
+			})
+	}
+
+	t.Run("interest on overdue debits and late fee on a missed minimum payment", func(t *testing.T) {
+		account := &models.Account{ID: 1, Currency: models.DefaultCurrency, AvailableCreditLimit: creditLimit(1)}
+		lockAccount(account)
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(1), models.AccrualInterest, day).Return(false, nil)
+		mockTransactionRepo.EXPECT().GetOverdueDebits(gomock.Any(), int64(1), day).Return([]*models.Transaction{
+			{ID: 1, OperationType: purchases, Balance: amount(-300)},
+			{ID: 2, OperationType: withdrawals, Balance: amount(-100.5)},
+		}, nil)
+		periodEnd := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+		mockStatementRepo.EXPECT().GetDueOn(gomock.Any(), int64(1), day).Return(&models.Statement{ID: 4, PeriodEnd: periodEnd, MinimumPayment: amount(45)}, nil)
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(1), models.AccrualLateFee, day).Return(false, nil)
+		mockTransactionRepo.EXPECT().GetPaidAmount(gomock.Any(), int64(1), periodEnd, day.AddDate(0, 0, 1)).Return(decimal.NewFromInt(20), nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil).Times(2)
+		// 300 * 12% / 30 + 100.5 * 15% / 30 = 1.7025
+		expectCharge(interest, "1.7")
+		// 2% of the 25 left to pay
+		expectCharge(lateFee, "0.5")
+
+		accruals, err := service.Accrue(context.Background(), day.Add(13*time.Hour))
+		assert.NoError(t, err)
+		if assert.Len(t, accruals, 2) {
+			assert.Equal(t, models.AccrualInterest, accruals[0].Kind)
+			assert.Equal(t, day, accruals[0].AccrualDate)
+			assert.Equal(t, int64(70), accruals[0].TransactionID)
+			assert.Nil(t, accruals[0].StatementID)
+			assert.Equal(t, models.AccrualLateFee, accruals[1].Kind)
+			assert.Equal(t, int64(4), *accruals[1].StatementID)
+		}
+		// charged over the limit
+		assert.Equal(t, "0", account.AvailableCreditLimit.String())
+		assert.Equal(t, "1.2", account.OverLimit.String())
+	})
+
+	t.Run("rerun charges nothing more", func(t *testing.T) {
+		lockAccount(&models.Account{ID: 1, Currency: models.DefaultCurrency})
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(1), models.AccrualInterest, day).Return(true, nil)
+		mockStatementRepo.EXPECT().GetDueOn(gomock.Any(), int64(1), day).Return(&models.Statement{ID: 4, MinimumPayment: amount(45)}, nil)
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(1), models.AccrualLateFee, day).Return(true, nil)
+
+		accruals, err := service.Accrue(context.Background(), day)
+		assert.NoError(t, err)
+		assert.Empty(t, accruals)
+	})
+
+	t.Run("nothing overdue and minimum payment made", func(t *testing.T) {
+		lockAccount(&models.Account{ID: 2, Currency: models.DefaultCurrency})
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(2), models.AccrualInterest, day).Return(false, nil)
+		mockTransactionRepo.EXPECT().GetOverdueDebits(gomock.Any(), int64(2), day).Return(nil, nil)
+		mockStatementRepo.EXPECT().GetDueOn(gomock.Any(), int64(2), day).Return(&models.Statement{ID: 5, MinimumPayment: amount(45)}, nil)
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(2), models.AccrualLateFee, day).Return(false, nil)
+		mockTransactionRepo.EXPECT().GetPaidAmount(gomock.Any(), int64(2), gomock.Any(), gomock.Any()).Return(decimal.NewFromInt(45), nil)
+
+		accruals, err := service.Accrue(context.Background(), day)
+		assert.NoError(t, err)
+		assert.Empty(t, accruals)
+	})
+
+	t.Run("no statement due", func(t *testing.T) {
+		lockAccount(&models.Account{ID: 3, Currency: models.DefaultCurrency})
+		mockAccrualRepo.EXPECT().Exists(gomock.Any(), int64(3), models.AccrualInterest, day).Return(false, nil)
+		mockTransactionRepo.EXPECT().GetOverdueDebits(gomock.Any(), int64(3), day).Return(nil, nil)
+		mockStatementRepo.EXPECT().GetDueOn(gomock.Any(), int64(3), day).Return(nil, sql.ErrNoRows)
+
+		accruals, err := service.Accrue(context.Background(), day)
+		assert.NoError(t, err)
+		assert.Empty(t, accruals)
+	})
+
+	t.Run("closed account is skipped", func(t *testing.T) {
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*models.Account{{ID: 4, Status: models.AccountStatusClosed}}, nil, nil)
+
+		accruals, err := service.Accrue(context.Background(), day)
+		assert.NoError(t, err)
+		assert.Empty(t, accruals)
+	})
+
+	t.Run("day not over yet", func(t *testing.T) {
+		accruals, err := service.Accrue(context.Background(), time.Now())
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, accruals)
+	})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+// CaptureTransaction completes a pending authorization, for its full amount or a lower one
+// The part of the hold that is not captured is given back to the available credit limit of the account
+// The captured amount is booked in the double-entry books, an authorization is not
+// A partial capture of a purchase made in another currency captures its original amount converted back with the same rate
+func (s *txnSrv) CaptureTransaction(ctx context.Context, id int64, req *api.CaptureTransactionRequest) (*models.Transaction, error) {
+	var txn *models.Transaction
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var account *models.Account
+		var err error
+		if txn, account, err = s.pendingForUpdate(ctx, id, models.TxnStatusCompleted); err != nil {
+			return err
+		}
+
+		authorized := txn.Amount.Abs()
+		amount := req.Amount.Abs()
+		if amount.IsZero() {
+			amount = authorized
+		}
+		if !txn.Currency.Fits(amount) {
+			return api.BadRequestErr(api.ErrAmountPrecision, nil)
+		}
+		if amount.GreaterThan(authorized) {
+			return api.UnprocessableEntityErr(api.ErrCaptureExceedsAmount, nil)
+		}
+		slog.Debug("CaptureTransaction", "transaction", id, "amount", amount, "authorized", authorized)
+
+		// the account is checked even when nothing is released, a closed account takes no capture
+		if err := s.moveCreditLimit(ctx, account, authorized.Sub(amount)); err != nil {
+			return err
+		}
+		// only debits can be authorized, so the captured amount is negative
+		txn.Amount = money.New(amount.Neg())
+		if txn.OriginalAmount != nil && amount.LessThan(authorized) {
+			original := money.New(txn.Amount.Div(*txn.FXRate).Round(txn.OriginalCurrency.Exponent()))
+			txn.OriginalAmount = &original
+		}
+		txn.Balance = txn.Amount
+		txn.Status = models.TxnStatusCompleted
+		if err := s.transactionRepo.Settle(ctx, txn); err != nil {
+			return err
+		}
+		return s.journal(ctx, txn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+// VoidTransaction cancels a pending authorization and releases its hold on the available credit limit
+// The transaction is kept as failed with its authorized amount and no balance
+func (s *txnSrv) VoidTransaction(ctx context.Context, id int64) (*models.Transaction, error) {
+	var txn *models.Transaction
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var account *models.Account
+		var err error
+		if txn, account, err = s.pendingForUpdate(ctx, id, models.TxnStatusFailed); err != nil {
+			return err
+		}
+		slog.Debug("VoidTransaction", "transaction", id, "amount", txn.Amount)
+
+		if err := s.moveCreditLimit(ctx, account, txn.Amount.Abs()); err != nil {
+			return err
+		}
+		txn.Balance = models.Money{}
+		txn.Status = models.TxnStatusFailed
+		return s.transactionRepo.Settle(ctx, txn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+// pendingForUpdate locks the account of a transaction and then the transaction until the DB transaction ends, and
+// checks the transaction can move to the next status
+func (s *txnSrv) pendingForUpdate(ctx context.Context, id int64, next models.TxnStatus) (*models.Transaction, *models.Account, error) {
+	txn, account, err := s.lockWithAccount(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canTransition(txn.Status, next) {
+		return nil, nil, api.ConflictErr(api.ErrNotPending, nil)
+	}
+	return txn, account, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, canTransition(models.TxnStatusPending, models.TxnStatusCompleted))
+	assert.True(t, canTransition(models.TxnStatusPending, models.TxnStatusFailed))
+	assert.True(t, canTransition(models.TxnStatusPartiallyRefunded, models.TxnStatusReversed))
+	assert.False(t, canTransition(models.TxnStatusPending, models.TxnStatusReversed))
+	assert.False(t, canTransition(models.TxnStatusCompleted, models.TxnStatusFailed))
+	assert.False(t, canTransition(models.TxnStatusFailed, models.TxnStatusCompleted))
+	assert.False(t, canTransition(models.TxnStatusReversed, models.TxnStatusPartiallyRefunded))
+}
+
+func TestCaptureTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Ledger: acceptJournal(ctrl)})
+
+	pending := func(id int64) *models.Transaction {
+		return &models.Transaction{ID: id, AccountID: 1, Currency: models.DefaultCurrency, OperationTypeID: 1, Status: models.TxnStatusPending,
+			Amount: amount(-100), Balance: amount(-100)}
+	}
+
+	t.Run("full capture", func(t *testing.T) {
+		account := &models.Account{ID: 1, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(900)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		// the account is locked before the authorization, in the same order as any other write to the account
+		gomock.InOrder(
+			mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(pending(1), nil),
+			mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil),
+			mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(pending(1), nil),
+		)
+		mockTransactionRepo.EXPECT().Settle(gomock.Any(), gomock.Any()).Return(nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 1, &api.CaptureTransactionRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, models.TxnStatusCompleted, txn.Status)
+		assert.True(t, decimal.NewFromFloat(-100).Equal(txn.Amount.Decimal))
+		assert.True(t, decimal.NewFromFloat(-100).Equal(txn.Balance.Decimal))
+	})
+
+	t.Run("partial capture releases the rest of the hold", func(t *testing.T) {
+		account := &models.Account{ID: 1, AvailableCreditLimit: creditLimit(900)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(pending(2), nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(2)).Return(pending(2), nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil)
+		mockTransactionRepo.EXPECT().Settle(gomock.Any(), gomock.Any()).Return(nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 2, &api.CaptureTransactionRequest{Amount: amount(60)})
+		assert.NoError(t, err)
+		assert.Equal(t, models.TxnStatusCompleted, txn.Status)
+		assert.True(t, decimal.NewFromFloat(-60).Equal(txn.Amount.Decimal))
+		assert.True(t, decimal.NewFromFloat(940).Equal(account.AvailableCreditLimit.Decimal))
+	})
+
+	t.Run("full capture on a closed account", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(pending(7), nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1, Status: models.AccountStatusClosed}, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(pending(7), nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 7, &api.CaptureTransactionRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrAccountClosed, he.Message)
+		assert.Nil(t, txn)
+	})
+
+	t.Run("partial capture of a converted purchase", func(t *testing.T) {
+		converted := pending(8)
+		converted.Amount, converted.Balance = amount(-108.64), amount(-108.64)
+		original, rate := amount(-20), decimal.RequireFromString("5.4321")
+		converted.OriginalAmount, converted.OriginalCurrency, converted.FXRate = &original, "USD", &rate
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(8), false).Return(converted, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(8)).Return(converted, nil)
+		mockTransactionRepo.EXPECT().Settle(gomock.Any(), converted).Return(nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 8, &api.CaptureTransactionRequest{Amount: amount(54.32)})
+		assert.NoError(t, err)
+		assert.Equal(t, "-54.32", txn.Amount.String())
+		assert.Equal(t, "-10", txn.OriginalAmount.String())
+		assert.True(t, rate.Equal(*txn.FXRate))
+	})
+
+	t.Run("capture exceeding the authorized amount", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(3), false).Return(pending(3), nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(3)).Return(pending(3), nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 3, &api.CaptureTransactionRequest{Amount: amount(100.01)})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, txn)
+	})
+
+	t.Run("capture with more decimals than the currency", func(t *testing.T) {
+		yen := pending(6)
+		yen.Currency = "JPY"
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(6), false).Return(yen, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(6)).Return(yen, nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 6, &api.CaptureTransactionRequest{Amount: amount(60.5)})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrAmountPrecision, he.Message)
+		assert.Nil(t, txn)
+	})
+
+	t.Run("already captured", func(t *testing.T) {
+		completed := pending(4)
+		completed.Status = models.TxnStatusCompleted
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(completed, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(4)).Return(completed, nil)
+
+		txn, err := service.CaptureTransaction(context.Background(), 4, &api.CaptureTransactionRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Nil(t, txn)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(5), false).Return(nil, sql.ErrNoRows)
+
+		txn, err := service.CaptureTransaction(context.Background(), 5, &api.CaptureTransactionRequest{})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, txn)
+	})
+}
+
+func TestVoidTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo})
+
+	t.Run("void releases the hold", func(t *testing.T) {
+		original := &models.Transaction{ID: 1, AccountID: 1, Currency: models.DefaultCurrency, Status: models.TxnStatusPending,
+			Amount: amount(-100), Balance: amount(-100)}
+		account := &models.Account{ID: 1, AvailableCreditLimit: creditLimit(900)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(original, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(original, nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil)
+		mockTransactionRepo.EXPECT().Settle(gomock.Any(), original).Return(nil)
+
+		txn, err := service.VoidTransaction(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, models.TxnStatusFailed, txn.Status)
+		assert.True(t, decimal.NewFromFloat(-100).Equal(txn.Amount.Decimal))
+		assert.True(t, decimal.Zero.Equal(txn.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(1000).Equal(account.AvailableCreditLimit.Decimal))
+	})
+
+	t.Run("already voided", func(t *testing.T) {
+		voided := &models.Transaction{ID: 2, AccountID: 1, Status: models.TxnStatusFailed}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(voided, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(2)).Return(voided, nil)
+
+		txn, err := service.VoidTransaction(context.Background(), 2)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Nil(t, txn)
+	})
+}
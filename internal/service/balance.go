@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+// CheckBalances compares the stored balance of every account with the one computed from scratch from its transactions
+// With repair set, each drifted balance is rebuilt with the account row locked so no transaction is booked meanwhile
+func (s *txnSrv) CheckBalances(ctx context.Context, req *api.CheckBalancesRequest) (*api.CheckBalancesResponse, error) {
+	drifts, err := s.transactionRepo.GetBalanceDrifts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.CheckBalancesResponse{Drifts: make([]*api.BalanceDrift, 0, len(drifts)), Repaired: req.Repair}
+	for _, drift := range drifts {
+		slog.Warn("CheckBalances", "account", drift.AccountID, "stored_credits", drift.StoredCredits, "stored_debits", drift.StoredDebits,
+			"computed_credits", drift.ComputedCredits, "computed_debits", drift.ComputedDebits)
+		res.Drifts = append(res.Drifts, &api.BalanceDrift{
+			AccountID:       drift.AccountID,
+			StoredBalance:   money.New(drift.StoredCredits.Add(drift.StoredDebits)),
+			ComputedBalance: money.New(drift.ComputedCredits.Add(drift.ComputedDebits)),
+		})
+		if !req.Repair {
+			continue
+		}
+		err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+			if _, err := s.accountRepo.GetByIDForUpdate(ctx, drift.AccountID); err != nil {
+				return err
+			}
+			_, err := s.transactionRepo.RebuildBalance(ctx, drift.AccountID)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo})
+
+	drift := &models.BalanceDrift{
+		AccountID:       3,
+		StoredCredits:   decimal.NewFromInt(100),
+		StoredDebits:    decimal.NewFromInt(-40),
+		ComputedCredits: decimal.NewFromInt(100),
+		ComputedDebits:  decimal.NewFromInt(-55),
+	}
+
+	t.Run("reports drifted balances", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetBalanceDrifts(gomock.Any()).Return([]*models.BalanceDrift{drift}, nil)
+
+		res, err := service.CheckBalances(context.Background(), &api.CheckBalancesRequest{})
+		assert.NoError(t, err)
+		assert.False(t, res.Repaired)
+		if assert.Len(t, res.Drifts, 1) {
+			assert.Equal(t, int64(3), res.Drifts[0].AccountID)
+			assert.Equal(t, "60", res.Drifts[0].StoredBalance.String())
+			assert.Equal(t, "45", res.Drifts[0].ComputedBalance.String())
+		}
+	})
+
+	t.Run("rebuilds drifted balances with the account locked", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetBalanceDrifts(gomock.Any()).Return([]*models.BalanceDrift{drift}, nil)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(3)).Return(&models.Account{ID: 3}, nil),
+			mockTransactionRepo.EXPECT().RebuildBalance(gomock.Any(), int64(3)).Return(&models.Balance{AccountID: 3}, nil),
+		)
+
+		res, err := service.CheckBalances(context.Background(), &api.CheckBalancesRequest{Repair: true})
+		assert.NoError(t, err)
+		assert.True(t, res.Repaired)
+		assert.Len(t, res.Drifts, 1)
+	})
+
+	t.Run("consistent balances", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetBalanceDrifts(gomock.Any()).Return(nil, nil)
+
+		res, err := service.CheckBalances(context.Background(), &api.CheckBalancesRequest{Repair: true})
+		assert.NoError(t, err)
+		assert.NotNil(t, res.Drifts)
+		assert.Empty(t, res.Drifts)
+	})
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/uptrace/bun"
+)
+
+// CreateCustomer registers the identity of a person or company, the document number is stored without punctuation
+// A document number can only belong to one customer
+func (s *txnSrv) CreateCustomer(ctx context.Context, req *api.CreateCustomerRequest) (*models.Customer, error) {
+	return s.customerRepo.Create(ctx, &models.Customer{
+		DocNum:    api.NormalizeDocument(req.DocNum),
+		Name:      req.Name,
+		BirthDate: &req.BirthDate.Time,
+	})
+}
+
+// GetCustomerAccounts fetches one page of the accounts of a customer ordered by ID
+// Fetches the customer first so that a missing customer results in a 404 instead of an empty page
+func (s *txnSrv) GetCustomerAccounts(ctx context.Context, customerID int64, req *api.ListCustomerAccountsRequest) (*api.Page[*models.Account], error) {
+	cursor, err := repo.DecodeCursor(req.Cursor, "")
+	if err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidCursor, err)
+	}
+	if _, err := s.customerRepo.GetByID(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	accounts, next, err := s.accountRepo.GetByCustomerID(ctx, customerID, repo.NewListQuery().Page(cursor, req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &api.Page[*models.Account]{Items: accounts, NextCursor: next.Encode()}, nil
+}
+
+// EraseCustomer honours an LGPD erasure request: the personal data of the customer is replaced by a random pseudonym
+// that cannot be traced back to the person, while the accounts and their transactions are kept so the books still balance
+// The status reasons of the accounts are free text and may hold personal data, so they are cleared as well, and so are
+// the responses stored for idempotent retries (e.g. of POST /customers) that hold the document number and name
+// Only customers whose accounts are all closed can be erased, and only once
+func (s *txnSrv) EraseCustomer(ctx context.Context, id int64, req *api.EraseCustomerRequest) (*models.Customer, error) {
+	var customer *models.Customer
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var err error
+		if customer, err = s.customerRepo.GetByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if customer.ErasedAt != nil {
+			return api.ConflictErr(api.ErrCustomerAlreadyErased, nil)
+		}
+		// locked so that no account can be reopened while the customer is erased
+		accounts, err := s.accountRepo.GetByCustomerIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if account.Status != models.AccountStatusClosed {
+				return api.UnprocessableEntityErr(api.ErrCustomerAccountsOpen, nil)
+			}
+		}
+		slog.Debug("EraseCustomer", "customer", id, "accounts", len(accounts), "requested_by", req.RequestedBy)
+
+		for _, account := range accounts {
+			if err := s.accountRepo.ClearStatusReason(ctx, account); err != nil {
+				return err
+			}
+		}
+		// the document number is a JSON string in every stored response of the customer and its accounts
+		if _, err := s.idempotencyRepo.Scrub(ctx, `"`+customer.DocNum+`"`); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		customer.DocNum = pseudonym()
+		customer.Name = ""
+		customer.BirthDate = nil
+		customer.ErasedAt = &now
+		customer.ErasedBy = req.RequestedBy
+		if err := s.customerRepo.Erase(ctx, customer); err != nil {
+			return err
+		}
+		customer.DocNum = models.ErasedPlaceholder
+		customer.Name = models.ErasedPlaceholder
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// pseudonym generates a random replacement for a document number, unique enough for the unique index on it
+func pseudonym() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return "erased:" + hex.EncodeToString(b)
+}
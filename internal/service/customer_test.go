@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCustomerRepo := mockRepo.NewMockCustomer(ctrl)
+	service := NewTransactionService(Repos{Customer: mockCustomerRepo})
+
+	t.Run("document number is normalized", func(t *testing.T) {
+		birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+		req := &api.CreateCustomerRequest{DocNum: "529.982.247-25", Name: "Maria Silva", BirthDate: &api.Date{Time: birthDate}}
+
+		mockCustomerRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, customer *models.Customer) (*models.Customer, error) {
+				assert.Equal(t, "52998224725", customer.DocNum)
+				assert.Equal(t, "Maria Silva", customer.Name)
+				assert.Equal(t, birthDate, *customer.BirthDate)
+				return customer, nil
+			})
+
+		customer, err := service.CreateCustomer(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "52998224725", customer.DocNum)
+	})
+}
+
+func TestGetCustomerAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockCustomerRepo := mockRepo.NewMockCustomer(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Customer: mockCustomerRepo})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expected := []*models.Account{{ID: 1, CustomerID: 7}, {ID: 2, CustomerID: 7}}
+
+		mockCustomerRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&models.Customer{ID: 7}, nil)
+		mockAccountRepo.EXPECT().GetByCustomerID(gomock.Any(), int64(7), gomock.Any()).Return(expected, &repo.Cursor{ID: 2}, nil)
+
+		page, err := service.GetCustomerAccounts(context.Background(), 7, &api.ListCustomerAccountsRequest{Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page.Items)
+		assert.NotEmpty(t, page.NextCursor)
+	})
+
+	t.Run("customer not found", func(t *testing.T) {
+		mockCustomerRepo.EXPECT().GetByID(gomock.Any(), int64(8)).Return(nil, sql.ErrNoRows)
+
+		page, err := service.GetCustomerAccounts(context.Background(), 8, &api.ListCustomerAccountsRequest{})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, page)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		page, err := service.GetCustomerAccounts(context.Background(), 7, &api.ListCustomerAccountsRequest{Cursor: "not a cursor"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, page)
+	})
+}
+
+func TestEraseCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockCustomerRepo := mockRepo.NewMockCustomer(ctrl)
+	mockIdempotencyKeyRepo := mockRepo.NewMockIdempotencyKey(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Customer: mockCustomerRepo, IdempotencyKey: mockIdempotencyKeyRepo})
+
+	lockCustomer := func(customer *models.Customer) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().GetByIDForUpdate(gomock.Any(), customer.ID).Return(customer, nil)
+	}
+
+	t.Run("all accounts closed", func(t *testing.T) {
+		birthDate := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+		lockCustomer(&models.Customer{ID: 1, DocNum: "52998224725", Name: "Maria Silva", BirthDate: &birthDate})
+		mockAccountRepo.EXPECT().GetByCustomerIDForUpdate(gomock.Any(), int64(1)).Return([]*models.Account{
+			{ID: 1, Status: models.AccountStatusClosed, StatusReason: "moving abroad"},
+			{ID: 2, Status: models.AccountStatusClosed},
+		}, nil)
+		mockAccountRepo.EXPECT().ClearStatusReason(gomock.Any(), gomock.Any()).Times(2)
+		mockIdempotencyKeyRepo.EXPECT().Scrub(gomock.Any(), `"52998224725"`).Return(int64(1), nil)
+		mockCustomerRepo.EXPECT().Erase(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, customer *models.Customer) error {
+				assert.Regexp(t, "^erased:[0-9a-f]{32}$", customer.DocNum)
+				assert.Empty(t, customer.Name)
+				assert.Nil(t, customer.BirthDate)
+				return nil
+			})
+
+		customer, err := service.EraseCustomer(context.Background(), 1, &api.EraseCustomerRequest{RequestedBy: "dpo@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, models.ErasedPlaceholder, customer.DocNum)
+		assert.Equal(t, models.ErasedPlaceholder, customer.Name)
+		assert.Equal(t, "dpo@example.com", customer.ErasedBy)
+		assert.NotNil(t, customer.ErasedAt)
+	})
+
+	t.Run("account not closed", func(t *testing.T) {
+		lockCustomer(&models.Customer{ID: 2})
+		mockAccountRepo.EXPECT().GetByCustomerIDForUpdate(gomock.Any(), int64(2)).Return([]*models.Account{
+			{ID: 3, Status: models.AccountStatusClosed},
+			{ID: 4, Status: models.AccountStatusBlocked},
+		}, nil)
+
+		customer, err := service.EraseCustomer(context.Background(), 2, &api.EraseCustomerRequest{RequestedBy: "dpo@example.com"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, customer)
+	})
+
+	t.Run("already erased", func(t *testing.T) {
+		erasedAt := time.Now()
+		lockCustomer(&models.Customer{ID: 3, ErasedAt: &erasedAt})
+
+		customer, err := service.EraseCustomer(context.Background(), 3, &api.EraseCustomerRequest{RequestedBy: "dpo@example.com"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Nil(t, customer)
+	})
+
+	t.Run("customer not found", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(4)).Return(nil, sql.ErrNoRows)
+
+		customer, err := service.EraseCustomer(context.Background(), 4, &api.EraseCustomerRequest{RequestedBy: "dpo@example.com"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, customer)
+	})
+}
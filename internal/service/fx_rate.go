@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// maxFXRate is the first rate that does not fit the NUMERIC(19,8) rate column
+var maxFXRate = decimal.New(1, 11)
+
+// CreateFXRate adds the rate of a currency pair for a validity window starting now unless given
+// A window cannot start in the past, where it would change the rate conversions already used
+// A new open ended rate replaces the open ended rate of the pair: the window of the previous rate is closed where the new
+// one starts, so rates are never overwritten and every converted transaction can be traced back to the rate it used
+// A bounded rate never closes the open one, as the pair would be left without a rate once it ends: any overlap with an
+// existing window is rejected by the DB (409)
+func (s *txnSrv) CreateFXRate(ctx context.Context, req *api.CreateFXRateRequest) (*models.FXRate, error) {
+	base := models.Currency(strings.ToUpper(req.BaseCurrency))
+	quote := models.Currency(strings.ToUpper(req.QuoteCurrency))
+	for _, currency := range []models.Currency{base, quote} {
+		if err := currency.Validate(); err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidCurrency, err)
+		}
+	}
+	if base == quote {
+		return nil, api.BadRequestErr(api.ErrSameCurrencyPair, nil)
+	}
+	if !req.Rate.IsPositive() || !req.Rate.Equal(req.Rate.Truncate(8)) || req.Rate.GreaterThanOrEqual(maxFXRate) {
+		return nil, api.BadRequestErr(api.ErrInvalidFXRate, nil)
+	}
+	now := time.Now().UTC()
+	validFrom := now
+	if req.ValidFrom != nil {
+		validFrom = req.ValidFrom.UTC()
+		if validFrom.Before(now) {
+			return nil, api.BadRequestErr(api.ErrValidFromInPast, nil)
+		}
+	}
+	if req.ValidTo != nil && !req.ValidTo.After(validFrom) {
+		return nil, api.BadRequestErr(api.ErrInvalidValidityWindow, nil)
+	}
+
+	rate := &models.FXRate{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          req.Rate,
+		ValidFrom:     validFrom,
+		ValidTo:       req.ValidTo,
+	}
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		open, err := s.fxRateRepo.GetOpenForUpdate(ctx, base, quote)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if open != nil && req.ValidTo == nil && open.ValidFrom.Before(validFrom) {
+			slog.Debug("CreateFXRate", "closing", open.ID, "at", validFrom)
+			open.ValidTo = &validFrom
+			if err := s.fxRateRepo.UpdateValidTo(ctx, open); err != nil {
+				return err
+			}
+		}
+		rate, err = s.fxRateRepo.Create(ctx, rate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// GetFXRates fetches one page of FX rates ordered by ID, optionally only the ones of a currency pair and/or valid at a time
+func (s *txnSrv) GetFXRates(ctx context.Context, req *api.ListFXRatesRequest) (*api.Page[*models.FXRate], error) {
+	cursor, err := repo.DecodeCursor(req.Cursor, "")
+	if err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidCursor, err)
+	}
+	q := repo.NewListQuery().Page(cursor, req.Limit)
+	if req.BaseCurrency != "" {
+		q.Eq("base_currency", strings.ToUpper(req.BaseCurrency))
+	}
+	if req.QuoteCurrency != "" {
+		q.Eq("quote_currency", strings.ToUpper(req.QuoteCurrency))
+	}
+	if req.At != nil {
+		q.Lte("valid_from", *req.At)
+		q.Where("?TableAlias.valid_to IS NULL OR ?TableAlias.valid_to > ?", *req.At)
+	}
+
+	rates, next, err := s.fxRateRepo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Page[*models.FXRate]{Items: rates, NextCursor: next.Encode()}, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateFXRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockFXRateRepo := mockRepo.NewMockFXRate(ctrl)
+	service := NewTransactionService(Repos{Transaction: mockTransactionRepo, FXRate: mockFXRateRepo})
+
+	validFrom := time.Now().UTC().Truncate(time.Second).AddDate(0, 0, 1)
+
+	t.Run("closes the window of the previous rate", func(t *testing.T) {
+		req := &api.CreateFXRateRequest{BaseCurrency: "usd", QuoteCurrency: "brl", Rate: decimal.RequireFromString("5.4321"), ValidFrom: &validFrom}
+		open := &models.FXRate{ID: 1, BaseCurrency: "USD", QuoteCurrency: "BRL", ValidFrom: validFrom.AddDate(0, -1, 0)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockFXRateRepo.EXPECT().GetOpenForUpdate(gomock.Any(), models.Currency("USD"), models.Currency("BRL")).Return(open, nil)
+		mockFXRateRepo.EXPECT().UpdateValidTo(gomock.Any(), open).Return(nil)
+		mockFXRateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, rate *models.FXRate) (*models.FXRate, error) {
+				assert.Equal(t, models.Currency("USD"), rate.BaseCurrency)
+				assert.Equal(t, models.Currency("BRL"), rate.QuoteCurrency)
+				assert.Equal(t, validFrom, rate.ValidFrom)
+				assert.Nil(t, rate.ValidTo)
+				return rate, nil
+			})
+
+		rate, err := service.CreateFXRate(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "5.4321", rate.Rate.String())
+		assert.Equal(t, validFrom, *open.ValidTo)
+	})
+
+	t.Run("first rate of the pair starts now", func(t *testing.T) {
+		req := &api.CreateFXRateRequest{BaseCurrency: "EUR", QuoteCurrency: "BRL", Rate: decimal.RequireFromString("6.1")}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockFXRateRepo.EXPECT().GetOpenForUpdate(gomock.Any(), models.Currency("EUR"), models.Currency("BRL")).Return(nil, sql.ErrNoRows)
+		mockFXRateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, rate *models.FXRate) (*models.FXRate, error) {
+				return rate, nil
+			})
+
+		rate, err := service.CreateFXRate(context.Background(), req)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), rate.ValidFrom, time.Minute)
+	})
+
+	t.Run("open rate starting later is left to the overlap constraint", func(t *testing.T) {
+		req := &api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.RequireFromString("5.5"), ValidFrom: &validFrom}
+		open := &models.FXRate{ID: 1, BaseCurrency: "USD", QuoteCurrency: "BRL", ValidFrom: validFrom.AddDate(0, 1, 0)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockFXRateRepo.EXPECT().GetOpenForUpdate(gomock.Any(), models.Currency("USD"), models.Currency("BRL")).Return(open, nil)
+		mockFXRateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, rate *models.FXRate) (*models.FXRate, error) {
+				return rate, nil
+			})
+
+		_, err := service.CreateFXRate(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Nil(t, open.ValidTo)
+	})
+
+	t.Run("bounded rate does not close the open rate", func(t *testing.T) {
+		validTo := validFrom.AddDate(0, 0, 7)
+		req := &api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.RequireFromString("5.5"), ValidFrom: &validFrom, ValidTo: &validTo}
+		open := &models.FXRate{ID: 1, BaseCurrency: "USD", QuoteCurrency: "BRL", ValidFrom: validFrom.AddDate(0, -1, 0)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockFXRateRepo.EXPECT().GetOpenForUpdate(gomock.Any(), models.Currency("USD"), models.Currency("BRL")).Return(open, nil)
+		mockFXRateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, rate *models.FXRate) (*models.FXRate, error) {
+				return rate, nil
+			})
+
+		_, err := service.CreateFXRate(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Nil(t, open.ValidTo)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		before := validFrom.Add(-time.Hour)
+		past := time.Now().Add(-time.Minute)
+		for _, tc := range []struct {
+			req *api.CreateFXRateRequest
+			msg string
+		}{
+			{&api.CreateFXRateRequest{BaseCurrency: "XYZ", QuoteCurrency: "BRL", Rate: decimal.NewFromInt(1)}, api.ErrInvalidCurrency},
+			{&api.CreateFXRateRequest{BaseCurrency: "BRL", QuoteCurrency: "brl", Rate: decimal.NewFromInt(1)}, api.ErrSameCurrencyPair},
+			{&api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.NewFromInt(-5)}, api.ErrInvalidFXRate},
+			{&api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.RequireFromString("5.123456789")}, api.ErrInvalidFXRate},
+			{&api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.New(1, 11)}, api.ErrInvalidFXRate},
+			{&api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.NewFromInt(5), ValidFrom: &validFrom, ValidTo: &before}, api.ErrInvalidValidityWindow},
+			{&api.CreateFXRateRequest{BaseCurrency: "USD", QuoteCurrency: "BRL", Rate: decimal.NewFromInt(5), ValidFrom: &past}, api.ErrValidFromInPast},
+		} {
+			rate, err := service.CreateFXRate(context.Background(), tc.req)
+			assert.Error(t, err)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, tc.msg, he.Message)
+			assert.Nil(t, rate)
+		}
+	})
+}
+
+func TestGetFXRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFXRateRepo := mockRepo.NewMockFXRate(ctrl)
+	service := NewTransactionService(Repos{FXRate: mockFXRateRepo})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		at := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+		expected := []*models.FXRate{{ID: 1, BaseCurrency: "USD", QuoteCurrency: "BRL"}}
+
+		mockFXRateRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(expected, &repo.Cursor{ID: 1}, nil)
+
+		page, err := service.GetFXRates(context.Background(), &api.ListFXRatesRequest{BaseCurrency: "usd", QuoteCurrency: "BRL", At: &at, Limit: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page.Items)
+		assert.NotEmpty(t, page.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		page, err := service.GetFXRates(context.Background(), &api.ListFXRatesRequest{Cursor: "%%%"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, page)
+	})
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/money"
+)
+
+// journal books a posted transaction in the double-entry books, it has to run in the DB transaction creating or settling it
+// The customer ledger account is debited what the transaction takes from the account (credited what it gives back)
+// and the other side goes to settlement. A transaction converted from another currency goes through the fx accounts:
+// the customer side is balanced by fx in the account currency and settlement by fx in the original currency
+// Interest and fees (and their reversals) are earned by the service, their other side goes to fees, which requires the
+// operation type to be set on the transaction
+func (s *txnSrv) journal(ctx context.Context, txn *models.Transaction) error {
+	if txn.Amount.IsZero() {
+		return nil
+	}
+	entry := &models.JournalEntry{TransactionID: txn.ID}
+	counterpart := models.LedgerSettlement
+	switch {
+	case txn.OriginalAmount != nil:
+		counterpart = models.LedgerFX
+	case txn.OperationType != nil && txn.OperationType.Fee():
+		counterpart = models.LedgerFees
+	}
+	if err := s.post(ctx, entry, models.CustomerLedgerAccount(txn.AccountID, txn.Currency), money.New(txn.Amount.Neg())); err != nil {
+		return err
+	}
+	if err := s.post(ctx, entry, models.SystemLedgerAccount(counterpart, txn.Currency), txn.Amount); err != nil {
+		return err
+	}
+	if txn.OriginalAmount != nil {
+		if err := s.post(ctx, entry, models.SystemLedgerAccount(models.LedgerFX, txn.OriginalCurrency), money.New(txn.OriginalAmount.Neg())); err != nil {
+			return err
+		}
+		if err := s.post(ctx, entry, models.SystemLedgerAccount(models.LedgerSettlement, txn.OriginalCurrency), *txn.OriginalAmount); err != nil {
+			return err
+		}
+	}
+	// the DB rejects it at commit anyway, failing here tells which transaction was wrongly booked
+	if !entry.Balanced() {
+		return fmt.Errorf("journal entry of transaction %d does not balance", txn.ID)
+	}
+	slog.Debug("journal", "transaction", txn.ID, "postings", len(entry.Postings))
+
+	_, err := s.ledgerRepo.CreateEntry(ctx, entry)
+	return err
+}
+
+// post adds a posting of a signed amount (positive for a debit) to the entry, creating the ledger account the first time
+func (s *txnSrv) post(ctx context.Context, entry *models.JournalEntry, account *models.LedgerAccount, amount models.Money) error {
+	account, err := s.ledgerRepo.FindOrCreateAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	entry.Postings = append(entry.Postings, &models.Posting{
+		LedgerAccountID: account.ID,
+		Amount:          amount,
+		Currency:        account.Currency,
+		LedgerAccount:   account,
+	})
+	return nil
+}
+
+// GetJournalEntry fetches the journal entry of a transaction along with its postings
+// Fetches the transaction first so that a missing transaction results in a 404, a pending or failed one has no entry (404)
+func (s *txnSrv) GetJournalEntry(ctx context.Context, transactionID int64) (*models.JournalEntry, error) {
+	if _, err := s.transactionRepo.GetByID(ctx, transactionID, false); err != nil {
+		return nil, err
+	}
+	return s.ledgerRepo.GetEntryByTransactionID(ctx, transactionID)
+}
+
+// GetTrialBalance fetches the debits, credits and balance of every ledger account
+func (s *txnSrv) GetTrialBalance(ctx context.Context) ([]*models.LedgerAccountBalance, error) {
+	return s.ledgerRepo.GetTrialBalance(ctx)
+}
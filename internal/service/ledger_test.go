@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// acceptJournal builds a ledger repo accepting any journal entry, for tests not about the books
+func acceptJournal(ctrl *gomock.Controller) *mockRepo.MockLedger {
+	mockLedgerRepo := mockRepo.NewMockLedger(ctrl)
+	mockLedgerRepo.EXPECT().FindOrCreateAccount(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, account *models.LedgerAccount) (*models.LedgerAccount, error) {
+			return account, nil
+		}).AnyTimes()
+	mockLedgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, entry *models.JournalEntry) (*models.JournalEntry, error) {
+			return entry, nil
+		}).AnyTimes()
+	return mockLedgerRepo
+}
+
+func TestJournal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLedgerRepo := mockRepo.NewMockLedger(ctrl)
+	service := NewTransactionService(Repos{Ledger: mockLedgerRepo}).(*txnSrv)
+
+	// ledger accounts get an ID the first time their code is seen
+	codes := map[int64]string{}
+	mockLedgerRepo.EXPECT().FindOrCreateAccount(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, account *models.LedgerAccount) (*models.LedgerAccount, error) {
+			for id, code := range codes {
+				if code == account.Code {
+					account.ID = id
+					return account, nil
+				}
+			}
+			account.ID = int64(len(codes) + 1)
+			codes[account.ID] = account.Code
+			return account, nil
+		}).AnyTimes()
+
+	// book returns the postings of the entry created for the transaction by ledger account code
+	book := func(txn *models.Transaction) map[string]string {
+		postings := map[string]string{}
+		mockLedgerRepo.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, entry *models.JournalEntry) (*models.JournalEntry, error) {
+				assert.Equal(t, txn.ID, entry.TransactionID)
+				for _, posting := range entry.Postings {
+					postings[codes[posting.LedgerAccountID]] = posting.Amount.String() + " " + posting.Currency.String()
+				}
+				return entry, nil
+			})
+
+		assert.NoError(t, service.journal(context.Background(), txn))
+		return postings
+	}
+
+	t.Run("purchase debits the customer against settlement", func(t *testing.T) {
+		postings := book(&models.Transaction{ID: 1, AccountID: 7, Currency: "BRL", Amount: amount(-50.5)})
+		assert.Equal(t, map[string]string{
+			"customer:7":     "50.5 BRL",
+			"settlement:BRL": "-50.5 BRL",
+		}, postings)
+	})
+
+	t.Run("payment credits the customer", func(t *testing.T) {
+		postings := book(&models.Transaction{ID: 2, AccountID: 7, Currency: "BRL", Amount: amount(60)})
+		assert.Equal(t, map[string]string{
+			"customer:7":     "-60 BRL",
+			"settlement:BRL": "60 BRL",
+		}, postings)
+	})
+
+	t.Run("converted purchase goes through the fx accounts", func(t *testing.T) {
+		original := amount(-10)
+		postings := book(&models.Transaction{ID: 3, AccountID: 7, Currency: "BRL", Amount: amount(-55), OriginalAmount: &original, OriginalCurrency: "USD"})
+		assert.Equal(t, map[string]string{
+			"customer:7":     "55 BRL",
+			"fx:BRL":         "-55 BRL",
+			"fx:USD":         "10 USD",
+			"settlement:USD": "-10 USD",
+		}, postings)
+	})
+
+	t.Run("interest is earned in fees", func(t *testing.T) {
+		postings := book(&models.Transaction{ID: 6, AccountID: 7, Currency: "BRL", Amount: amount(-1.25),
+			OperationType: &models.OperationType{Code: models.OpCodeInterest}})
+		assert.Equal(t, map[string]string{
+			"customer:7": "1.25 BRL",
+			"fees:BRL":   "-1.25 BRL",
+		}, postings)
+	})
+
+	t.Run("nothing to book for a zero amount", func(t *testing.T) {
+		assert.NoError(t, service.journal(context.Background(), &models.Transaction{ID: 4, AccountID: 7, Currency: "BRL"}))
+	})
+
+	t.Run("ledger account error", func(t *testing.T) {
+		mockLedgerRepo := mockRepo.NewMockLedger(ctrl)
+		service := NewTransactionService(Repos{Ledger: mockLedgerRepo}).(*txnSrv)
+		mockLedgerRepo.EXPECT().FindOrCreateAccount(gomock.Any(), gomock.Any()).Return(nil, sql.ErrConnDone)
+
+		err := service.journal(context.Background(), &models.Transaction{ID: 5, AccountID: 7, Currency: "BRL", Amount: amount(-1)})
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
+func TestJournalEntryBalanced(t *testing.T) {
+	entry := &models.JournalEntry{Postings: []*models.Posting{
+		{Amount: amount(55), Currency: "BRL"},
+		{Amount: amount(-55), Currency: "BRL"},
+		{Amount: amount(10), Currency: "USD"},
+		{Amount: amount(-10), Currency: "USD"},
+	}}
+	assert.True(t, entry.Balanced())
+
+	// the same total across two currencies does not balance
+	entry.Postings[3].Currency = "BRL"
+	assert.False(t, entry.Balanced())
+}
@@ -12,6 +12,7 @@ package mockService
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/akhiltak/pismo-api/internal/storage/models"
 	api "github.com/akhiltak/pismo-api/pkg/api"
@@ -42,6 +43,81 @@ func (m *MockTransactionService) EXPECT() *MockTransactionServiceMockRecorder {
 	return m.recorder
 }
 
+// Accrue mocks base method.
+func (m *MockTransactionService) Accrue(arg0 context.Context, arg1 time.Time) ([]*models.Accrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accrue", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Accrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accrue indicates an expected call of Accrue.
+func (mr *MockTransactionServiceMockRecorder) Accrue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accrue", reflect.TypeOf((*MockTransactionService)(nil).Accrue), arg0, arg1)
+}
+
+// CancelScheduledTransaction mocks base method.
+func (m *MockTransactionService) CancelScheduledTransaction(arg0 context.Context, arg1 int64) (*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledTransaction indicates an expected call of CancelScheduledTransaction.
+func (mr *MockTransactionServiceMockRecorder) CancelScheduledTransaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledTransaction", reflect.TypeOf((*MockTransactionService)(nil).CancelScheduledTransaction), arg0, arg1)
+}
+
+// CaptureTransaction mocks base method.
+func (m *MockTransactionService) CaptureTransaction(arg0 context.Context, arg1 int64, arg2 *api.CaptureTransactionRequest) (*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureTransaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureTransaction indicates an expected call of CaptureTransaction.
+func (mr *MockTransactionServiceMockRecorder) CaptureTransaction(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureTransaction", reflect.TypeOf((*MockTransactionService)(nil).CaptureTransaction), arg0, arg1, arg2)
+}
+
+// CheckBalances mocks base method.
+func (m *MockTransactionService) CheckBalances(arg0 context.Context, arg1 *api.CheckBalancesRequest) (*api.CheckBalancesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBalances", arg0, arg1)
+	ret0, _ := ret[0].(*api.CheckBalancesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckBalances indicates an expected call of CheckBalances.
+func (mr *MockTransactionServiceMockRecorder) CheckBalances(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBalances", reflect.TypeOf((*MockTransactionService)(nil).CheckBalances), arg0, arg1)
+}
+
+// CloseStatements mocks base method.
+func (m *MockTransactionService) CloseStatements(arg0 context.Context, arg1 time.Time) ([]*models.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseStatements", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseStatements indicates an expected call of CloseStatements.
+func (mr *MockTransactionServiceMockRecorder) CloseStatements(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseStatements", reflect.TypeOf((*MockTransactionService)(nil).CloseStatements), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockTransactionService) CreateAccount(arg0 context.Context, arg1 *api.CreateAccountRequest) (*models.Account, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +133,66 @@ func (mr *MockTransactionServiceMockRecorder) CreateAccount(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockTransactionService)(nil).CreateAccount), arg0, arg1)
 }
 
+// CreateCustomer mocks base method.
+func (m *MockTransactionService) CreateCustomer(arg0 context.Context, arg1 *api.CreateCustomerRequest) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", arg0, arg1)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockTransactionServiceMockRecorder) CreateCustomer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockTransactionService)(nil).CreateCustomer), arg0, arg1)
+}
+
+// CreateFXRate mocks base method.
+func (m *MockTransactionService) CreateFXRate(arg0 context.Context, arg1 *api.CreateFXRateRequest) (*models.FXRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFXRate", arg0, arg1)
+	ret0, _ := ret[0].(*models.FXRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFXRate indicates an expected call of CreateFXRate.
+func (mr *MockTransactionServiceMockRecorder) CreateFXRate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFXRate", reflect.TypeOf((*MockTransactionService)(nil).CreateFXRate), arg0, arg1)
+}
+
+// CreateOperationType mocks base method.
+func (m *MockTransactionService) CreateOperationType(arg0 context.Context, arg1 *api.OperationTypeRequest) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperationType", arg0, arg1)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOperationType indicates an expected call of CreateOperationType.
+func (mr *MockTransactionServiceMockRecorder) CreateOperationType(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperationType", reflect.TypeOf((*MockTransactionService)(nil).CreateOperationType), arg0, arg1)
+}
+
+// CreateScheduledTransaction mocks base method.
+func (m *MockTransactionService) CreateScheduledTransaction(arg0 context.Context, arg1 *api.CreateScheduledTransactionRequest) (*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransaction indicates an expected call of CreateScheduledTransaction.
+func (mr *MockTransactionServiceMockRecorder) CreateScheduledTransaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransaction", reflect.TypeOf((*MockTransactionService)(nil).CreateScheduledTransaction), arg0, arg1)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockTransactionService) CreateTransaction(arg0 context.Context, arg1 *api.CreateTransactionRequest) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +208,51 @@ func (mr *MockTransactionServiceMockRecorder) CreateTransaction(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockTransactionService)(nil).CreateTransaction), arg0, arg1)
 }
 
+// CreateTransfer mocks base method.
+func (m *MockTransactionService) CreateTransfer(arg0 context.Context, arg1 *api.CreateTransferRequest) (*models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(*models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransactionServiceMockRecorder) CreateTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransactionService)(nil).CreateTransfer), arg0, arg1)
+}
+
+// DeactivateOperationType mocks base method.
+func (m *MockTransactionService) DeactivateOperationType(arg0 context.Context, arg1 int64) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateOperationType", arg0, arg1)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateOperationType indicates an expected call of DeactivateOperationType.
+func (mr *MockTransactionServiceMockRecorder) DeactivateOperationType(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOperationType", reflect.TypeOf((*MockTransactionService)(nil).DeactivateOperationType), arg0, arg1)
+}
+
+// EraseCustomer mocks base method.
+func (m *MockTransactionService) EraseCustomer(arg0 context.Context, arg1 int64, arg2 *api.EraseCustomerRequest) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseCustomer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseCustomer indicates an expected call of EraseCustomer.
+func (mr *MockTransactionServiceMockRecorder) EraseCustomer(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseCustomer", reflect.TypeOf((*MockTransactionService)(nil).EraseCustomer), arg0, arg1, arg2)
+}
+
 // GetAccountByID mocks base method.
 func (m *MockTransactionService) GetAccountByID(arg0 context.Context, arg1 int64) (*models.Account, error) {
 	m.ctrl.T.Helper()
@@ -87,17 +268,287 @@ func (mr *MockTransactionServiceMockRecorder) GetAccountByID(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockTransactionService)(nil).GetAccountByID), arg0, arg1)
 }
 
+// GetAccountStatements mocks base method.
+func (m *MockTransactionService) GetAccountStatements(arg0 context.Context, arg1 int64, arg2 *api.ListStatementsRequest) (*api.Page[*models.Statement], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStatements", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.Page[*models.Statement])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStatements indicates an expected call of GetAccountStatements.
+func (mr *MockTransactionServiceMockRecorder) GetAccountStatements(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatements", reflect.TypeOf((*MockTransactionService)(nil).GetAccountStatements), arg0, arg1, arg2)
+}
+
+// GetAccountTransactions mocks base method.
+func (m *MockTransactionService) GetAccountTransactions(arg0 context.Context, arg1 int64, arg2 *api.ListAccountTransactionsRequest) (*api.Page[*models.Transaction], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransactions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.Page[*models.Transaction])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransactions indicates an expected call of GetAccountTransactions.
+func (mr *MockTransactionServiceMockRecorder) GetAccountTransactions(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactions", reflect.TypeOf((*MockTransactionService)(nil).GetAccountTransactions), arg0, arg1, arg2)
+}
+
+// GetAccounts mocks base method.
+func (m *MockTransactionService) GetAccounts(arg0 context.Context, arg1 *api.ListAccountsRequest) (*api.Page[*models.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts", arg0, arg1)
+	ret0, _ := ret[0].(*api.Page[*models.Account])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockTransactionServiceMockRecorder) GetAccounts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockTransactionService)(nil).GetAccounts), arg0, arg1)
+}
+
+// GetBalance mocks base method.
+func (m *MockTransactionService) GetBalance(arg0 context.Context, arg1 int64) (*api.AccountBalanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", arg0, arg1)
+	ret0, _ := ret[0].(*api.AccountBalanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockTransactionServiceMockRecorder) GetBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockTransactionService)(nil).GetBalance), arg0, arg1)
+}
+
+// GetCustomerAccounts mocks base method.
+func (m *MockTransactionService) GetCustomerAccounts(arg0 context.Context, arg1 int64, arg2 *api.ListCustomerAccountsRequest) (*api.Page[*models.Account], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerAccounts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*api.Page[*models.Account])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerAccounts indicates an expected call of GetCustomerAccounts.
+func (mr *MockTransactionServiceMockRecorder) GetCustomerAccounts(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerAccounts", reflect.TypeOf((*MockTransactionService)(nil).GetCustomerAccounts), arg0, arg1, arg2)
+}
+
+// GetFXRates mocks base method.
+func (m *MockTransactionService) GetFXRates(arg0 context.Context, arg1 *api.ListFXRatesRequest) (*api.Page[*models.FXRate], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFXRates", arg0, arg1)
+	ret0, _ := ret[0].(*api.Page[*models.FXRate])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFXRates indicates an expected call of GetFXRates.
+func (mr *MockTransactionServiceMockRecorder) GetFXRates(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFXRates", reflect.TypeOf((*MockTransactionService)(nil).GetFXRates), arg0, arg1)
+}
+
+// GetInstallments mocks base method.
+func (m *MockTransactionService) GetInstallments(arg0 context.Context, arg1 int64) ([]*models.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstallments", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstallments indicates an expected call of GetInstallments.
+func (mr *MockTransactionServiceMockRecorder) GetInstallments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstallments", reflect.TypeOf((*MockTransactionService)(nil).GetInstallments), arg0, arg1)
+}
+
+// GetJournalEntry mocks base method.
+func (m *MockTransactionService) GetJournalEntry(arg0 context.Context, arg1 int64) (*models.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalEntry", arg0, arg1)
+	ret0, _ := ret[0].(*models.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalEntry indicates an expected call of GetJournalEntry.
+func (mr *MockTransactionServiceMockRecorder) GetJournalEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntry", reflect.TypeOf((*MockTransactionService)(nil).GetJournalEntry), arg0, arg1)
+}
+
+// GetOperationTypes mocks base method.
+func (m *MockTransactionService) GetOperationTypes(arg0 context.Context, arg1 *api.ListOperationTypesRequest) ([]*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationTypes", arg0, arg1)
+	ret0, _ := ret[0].([]*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationTypes indicates an expected call of GetOperationTypes.
+func (mr *MockTransactionServiceMockRecorder) GetOperationTypes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationTypes", reflect.TypeOf((*MockTransactionService)(nil).GetOperationTypes), arg0, arg1)
+}
+
+// GetScheduledTransaction mocks base method.
+func (m *MockTransactionService) GetScheduledTransaction(arg0 context.Context, arg1 int64) (*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransaction indicates an expected call of GetScheduledTransaction.
+func (mr *MockTransactionServiceMockRecorder) GetScheduledTransaction(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransaction", reflect.TypeOf((*MockTransactionService)(nil).GetScheduledTransaction), arg0, arg1)
+}
+
+// GetStatement mocks base method.
+func (m *MockTransactionService) GetStatement(arg0 context.Context, arg1 int64) (*models.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", arg0, arg1)
+	ret0, _ := ret[0].(*models.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockTransactionServiceMockRecorder) GetStatement(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockTransactionService)(nil).GetStatement), arg0, arg1)
+}
+
 // GetTransactions mocks base method.
-func (m *MockTransactionService) GetTransactions(arg0 context.Context) ([]*models.Transaction, error) {
+func (m *MockTransactionService) GetTransactions(arg0 context.Context, arg1 *api.ListTransactionsRequest) (*api.Page[*models.Transaction], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactions", arg0)
-	ret0, _ := ret[0].([]*models.Transaction)
+	ret := m.ctrl.Call(m, "GetTransactions", arg0, arg1)
+	ret0, _ := ret[0].(*api.Page[*models.Transaction])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTransactions indicates an expected call of GetTransactions.
-func (mr *MockTransactionServiceMockRecorder) GetTransactions(arg0 any) *gomock.Call {
+func (mr *MockTransactionServiceMockRecorder) GetTransactions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockTransactionService)(nil).GetTransactions), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockTransactionService) GetTransfer(arg0 context.Context, arg1 int64) (*models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(*models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockTransactionServiceMockRecorder) GetTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockTransactionService)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockTransactionService) GetTrialBalance(arg0 context.Context) ([]*models.LedgerAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", arg0)
+	ret0, _ := ret[0].([]*models.LedgerAccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockTransactionServiceMockRecorder) GetTrialBalance(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockTransactionService)(nil).GetTrialBalance), arg0)
+}
+
+// ReverseTransaction mocks base method.
+func (m *MockTransactionService) ReverseTransaction(arg0 context.Context, arg1 int64, arg2 *api.ReverseTransactionRequest) (*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransaction indicates an expected call of ReverseTransaction.
+func (mr *MockTransactionServiceMockRecorder) ReverseTransaction(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransaction", reflect.TypeOf((*MockTransactionService)(nil).ReverseTransaction), arg0, arg1, arg2)
+}
+
+// RunScheduledTransactions mocks base method.
+func (m *MockTransactionService) RunScheduledTransactions(arg0 context.Context, arg1 time.Time, arg2 time.Duration) ([]*models.ScheduledExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunScheduledTransactions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.ScheduledExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunScheduledTransactions indicates an expected call of RunScheduledTransactions.
+func (mr *MockTransactionServiceMockRecorder) RunScheduledTransactions(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduledTransactions", reflect.TypeOf((*MockTransactionService)(nil).RunScheduledTransactions), arg0, arg1, arg2)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockTransactionService) UpdateAccount(arg0 context.Context, arg1 int64, arg2 *api.UpdateAccountRequest) (*models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockTransactionServiceMockRecorder) UpdateAccount(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockTransactionService)(nil).UpdateAccount), arg0, arg1, arg2)
+}
+
+// UpdateOperationType mocks base method.
+func (m *MockTransactionService) UpdateOperationType(arg0 context.Context, arg1 int64, arg2 *api.OperationTypeRequest) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOperationType", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOperationType indicates an expected call of UpdateOperationType.
+func (mr *MockTransactionServiceMockRecorder) UpdateOperationType(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOperationType", reflect.TypeOf((*MockTransactionService)(nil).UpdateOperationType), arg0, arg1, arg2)
+}
+
+// VoidTransaction mocks base method.
+func (m *MockTransactionService) VoidTransaction(arg0 context.Context, arg1 int64) (*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidTransaction indicates an expected call of VoidTransaction.
+func (mr *MockTransactionServiceMockRecorder) VoidTransaction(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockTransactionService)(nil).GetTransactions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidTransaction", reflect.TypeOf((*MockTransactionService)(nil).VoidTransaction), arg0, arg1)
 }
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// GetOperationTypes lists the operation types, optionally filtered on whether they are active
+func (s *txnSrv) GetOperationTypes(ctx context.Context, req *api.ListOperationTypesRequest) ([]*models.OperationType, error) {
+	return s.operationRepo.List(ctx, req.Active)
+}
+
+// CreateOperationType creates a new active operation type
+// A debit type can be given a monthly interest rate, charged by the accrual job on its overdue debits
+func (s *txnSrv) CreateOperationType(ctx context.Context, req *api.OperationTypeRequest) (*models.OperationType, error) {
+	entryType := models.EntryType(req.EntryType)
+	if err := entryType.Validate(); err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidEntryType, err)
+	}
+	if !validInterestRate(entryType, req.InterestRate) {
+		return nil, api.BadRequestErr(api.ErrInvalidInterestRate, nil)
+	}
+
+	return s.operationRepo.Create(ctx, &models.OperationType{
+		Description:  req.Description,
+		EntryType:    entryType,
+		Installments: req.Installments,
+		InterestRate: req.InterestRate,
+		Active:       true,
+	})
+}
+
+// UpdateOperationType updates the description, entry type, installments flag and interest rate of an operation type
+// The entry type gives the sign of the transactions booked with the type, it can only change while there is none:
+// the type row is locked while it is checked and updated, transactions being created with it hold it shared
+// A new interest rate applies from the next accrual on, to the debits already booked as well
+func (s *txnSrv) UpdateOperationType(ctx context.Context, id int64, req *api.OperationTypeRequest) (*models.OperationType, error) {
+	entryType := models.EntryType(req.EntryType)
+	if err := entryType.Validate(); err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidEntryType, err)
+	}
+	if !validInterestRate(entryType, req.InterestRate) {
+		return nil, api.BadRequestErr(api.ErrInvalidInterestRate, nil)
+	}
+
+	var operation *models.OperationType
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var err error
+		if operation, err = s.operationRepo.GetByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if operation.Code != "" {
+			return api.UnprocessableEntityErr(api.ErrOpTypeReserved, nil)
+		}
+		if entryType != operation.EntryType {
+			used, err := s.operationRepo.HasTransactions(ctx, id)
+			if err != nil {
+				return err
+			}
+			if used {
+				return api.ConflictErr(api.ErrEntryTypeInUse, nil)
+			}
+		}
+		operation.Description = req.Description
+		operation.EntryType = entryType
+		operation.Installments = req.Installments
+		operation.InterestRate = req.InterestRate
+		return s.operationRepo.Update(ctx, operation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// DeactivateOperationType prevents an operation type from being used for new transactions
+// The types reserved to the service (e.g. transfers) can neither be updated nor deactivated
+// Operation types are never deleted, so the transactions booked with them keep their meaning
+func (s *txnSrv) DeactivateOperationType(ctx context.Context, id int64) (*models.OperationType, error) {
+	operation, err := s.operationRepo.GetByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Code != "" {
+		return nil, api.UnprocessableEntityErr(api.ErrOpTypeReserved, nil)
+	}
+	if !operation.Active {
+		return operation, nil
+	}
+	if err := s.operationRepo.Deactivate(ctx, operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// validInterestRate tells whether an operation type of the entry type can have the monthly interest rate, none is always valid
+func validInterestRate(entryType models.EntryType, rate *decimal.Decimal) bool {
+	if rate == nil {
+		return true
+	}
+	return entryType == models.DebitEntry && !rate.IsNegative() && rate.LessThanOrEqual(decimal.NewFromInt(1)) && rate.Equal(rate.Truncate(6))
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetOperationTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	service := NewTransactionService(Repos{Operation: mockOperationRepo})
+
+	t.Run("only active", func(t *testing.T) {
+		active := true
+		expected := []*models.OperationType{{ID: 1, EntryType: models.DebitEntry, Active: true}}
+
+		mockOperationRepo.EXPECT().List(gomock.Any(), &active).Return(expected, nil)
+
+		operations, err := service.GetOperationTypes(context.Background(), &api.ListOperationTypesRequest{Active: &active})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, operations)
+	})
+}
+
+func TestCreateOperationType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	service := NewTransactionService(Repos{Operation: mockOperationRepo})
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockOperationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, operation *models.OperationType) (*models.OperationType, error) {
+				assert.Equal(t, models.CreditEntry, operation.EntryType)
+				assert.True(t, operation.Active)
+				operation.ID = 5
+				return operation, nil
+			})
+
+		operation, err := service.CreateOperationType(context.Background(), &api.OperationTypeRequest{Description: "Cashback", EntryType: "credit"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), operation.ID)
+	})
+
+	t.Run("invalid entry type", func(t *testing.T) {
+		operation, err := service.CreateOperationType(context.Background(), &api.OperationTypeRequest{Description: "Cashback", EntryType: "refund"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, operation)
+	})
+
+	t.Run("debit with an interest rate", func(t *testing.T) {
+		rate := decimal.RequireFromString("0.1299")
+		mockOperationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, operation *models.OperationType) (*models.OperationType, error) {
+				assert.Equal(t, &rate, operation.InterestRate)
+				return operation, nil
+			})
+
+		_, err := service.CreateOperationType(context.Background(), &api.OperationTypeRequest{Description: "Cash advance", EntryType: "debit", InterestRate: &rate})
+		assert.NoError(t, err)
+	})
+
+	for name, req := range map[string]*api.OperationTypeRequest{
+		"interest rate on a credit":     {Description: "Cashback", EntryType: "credit", InterestRate: decimalPtr("0.1")},
+		"interest rate above 100%":      {Description: "Cash advance", EntryType: "debit", InterestRate: decimalPtr("1.5")},
+		"negative interest rate":        {Description: "Cash advance", EntryType: "debit", InterestRate: decimalPtr("-0.1")},
+		"interest rate with 7 decimals": {Description: "Cash advance", EntryType: "debit", InterestRate: decimalPtr("0.1234567")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			operation, err := service.CreateOperationType(context.Background(), req)
+			assert.Error(t, err)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Nil(t, operation)
+		})
+	}
+}
+
+func decimalPtr(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
+
+func TestUpdateOperationType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Operation: mockOperationRepo, Transaction: mockTransactionRepo})
+
+	t.Run("successful update", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockOperationRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(3)).Return(&models.OperationType{ID: 3, Description: "Withdrawal", EntryType: models.DebitEntry, Active: true}, nil)
+		mockOperationRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		operation, err := service.UpdateOperationType(context.Background(), 3, &api.OperationTypeRequest{Description: "ATM Withdrawal", EntryType: "debit"})
+		assert.NoError(t, err)
+		assert.Equal(t, "ATM Withdrawal", operation.Description)
+		assert.True(t, operation.Active)
+	})
+
+	t.Run("invalid entry type", func(t *testing.T) {
+		operation, err := service.UpdateOperationType(context.Background(), 3, &api.OperationTypeRequest{Description: "Withdrawal", EntryType: "both"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, operation)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockOperationRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(9)).Return(nil, sql.ErrNoRows)
+
+		operation, err := service.UpdateOperationType(context.Background(), 9, &api.OperationTypeRequest{Description: "Withdrawal", EntryType: "debit"})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, operation)
+	})
+
+	t.Run("reserved for transfers", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockOperationRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(6)).Return(&models.OperationType{ID: 6, EntryType: models.CreditEntry, Active: true, Code: models.OpCodeTransferIn}, nil)
+
+		operation, err := service.UpdateOperationType(context.Background(), 6, &api.OperationTypeRequest{Description: "Transfer", EntryType: "credit"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, operation)
+	})
+
+	t.Run("entry type change without transactions", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockOperationRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(&models.OperationType{ID: 7, Description: "Cashback", EntryType: models.DebitEntry, Active: true}, nil)
+		mockOperationRepo.EXPECT().HasTransactions(gomock.Any(), int64(7)).Return(false, nil)
+		mockOperationRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		operation, err := service.UpdateOperationType(context.Background(), 7, &api.OperationTypeRequest{Description: "Cashback", EntryType: "credit"})
+		assert.NoError(t, err)
+		assert.Equal(t, models.CreditEntry, operation.EntryType)
+	})
+
+	t.Run("entry type change with transactions", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockOperationRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(3)).Return(&models.OperationType{ID: 3, Description: "Withdrawal", EntryType: models.DebitEntry, Active: true}, nil)
+		mockOperationRepo.EXPECT().HasTransactions(gomock.Any(), int64(3)).Return(true, nil)
+
+		operation, err := service.UpdateOperationType(context.Background(), 3, &api.OperationTypeRequest{Description: "Withdrawal", EntryType: "credit"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Nil(t, operation)
+	})
+}
+
+func TestDeactivateOperationType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	service := NewTransactionService(Repos{Operation: mockOperationRepo})
+
+	t.Run("successful deactivation", func(t *testing.T) {
+		active := &models.OperationType{ID: 3, EntryType: models.DebitEntry, Active: true}
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(3), false).Return(active, nil)
+		mockOperationRepo.EXPECT().Deactivate(gomock.Any(), active).Return(nil)
+
+		operation, err := service.DeactivateOperationType(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, active, operation)
+	})
+
+	t.Run("already inactive", func(t *testing.T) {
+		inactive := &models.OperationType{ID: 3, EntryType: models.DebitEntry}
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(3), false).Return(inactive, nil)
+
+		operation, err := service.DeactivateOperationType(context.Background(), 3)
+		assert.NoError(t, err)
+		assert.False(t, operation.Active)
+	})
+
+	t.Run("reserved for transfers", func(t *testing.T) {
+		reserved := &models.OperationType{ID: 5, EntryType: models.DebitEntry, Active: true, Code: models.OpCodeTransferOut}
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(5), false).Return(reserved, nil)
+
+		operation, err := service.DeactivateOperationType(context.Background(), 5)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, operation)
+	})
+}
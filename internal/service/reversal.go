@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// ReverseTransaction creates a compensating transaction with the opposite sign of the original one
+// The amount defaults to whatever was not refunded yet, partial refunds are allowed up to the original amount
+// Runs in a single DB transaction with the account and then the original row locked, so concurrent refunds cannot exceed
+// the original amount
+// The two sides of a transfer cannot be reversed, reversing only one of them would create or destroy money
+func (s *txnSrv) ReverseTransaction(ctx context.Context, id int64, req *api.ReverseTransactionRequest) (*models.Transaction, error) {
+	var reversal *models.Transaction
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		original, account, err := s.lockWithAccount(ctx, id)
+		if err != nil {
+			return err
+		}
+		if original.ReversedTxnID != nil {
+			return api.UnprocessableEntityErr(api.ErrReverseReversal, nil)
+		}
+		if original.TransferID != nil {
+			return api.UnprocessableEntityErr(api.ErrReverseTransfer, nil)
+		}
+		if !canTransition(original.Status, models.TxnStatusReversed) {
+			if original.Status == models.TxnStatusReversed {
+				return api.ConflictErr(api.ErrAlreadyReversed, nil)
+			}
+			return api.UnprocessableEntityErr(api.ErrNotReversible, nil)
+		}
+
+		refunded, err := s.transactionRepo.GetRefundedAmount(ctx, id)
+		if err != nil {
+			return err
+		}
+		refundable := original.Amount.Abs().Sub(refunded.Abs())
+		amount := req.Amount.Abs()
+		if amount.IsZero() {
+			amount = refundable
+		}
+		if !original.Currency.Fits(amount) {
+			return api.BadRequestErr(api.ErrAmountPrecision, nil)
+		}
+		if amount.GreaterThan(refundable) {
+			return api.UnprocessableEntityErr(api.ErrRefundExceedsAmount, nil)
+		}
+		slog.Debug("ReverseTransaction", "transaction", id, "amount", amount, "refundable", refundable)
+
+		// the compensating transaction has the opposite sign of the original one
+		reversal = &models.Transaction{
+			AccountID:       original.AccountID,
+			OperationTypeID: original.OperationTypeID,
+			Currency:        original.Currency,
+			Status:          models.TxnStatusCompleted,
+			Amount:          money.New(amount),
+			ReversedTxnID:   &original.ID,
+		}
+		if original.Amount.IsPositive() {
+			reversal.Amount = money.New(amount.Neg())
+		}
+		if err := s.moveCreditLimit(ctx, account, reversal.Amount.Decimal); err != nil {
+			return err
+		}
+		left, err := s.offset(ctx, original, reversal.Amount.Decimal)
+		if err != nil {
+			return err
+		}
+		reversal.Balance = money.New(left)
+
+		original.Status = models.TxnStatusPartiallyRefunded
+		if amount.Equal(refundable) {
+			original.Status = models.TxnStatusReversed
+		}
+		if err := s.transactionRepo.UpdateStatus(ctx, original); err != nil {
+			return err
+		}
+		if reversal, err = s.transactionRepo.Create(ctx, reversal); err != nil {
+			return err
+		}
+		// a waived interest or fee is taken back from the fees ledger account, see journal
+		if reversal.OperationType, err = s.operationRepo.GetByID(ctx, original.OperationTypeID, false); err != nil {
+			return err
+		}
+		return s.journal(ctx, reversal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// offset settles the compensating amount against the remaining balance of the original transaction first
+// and returns the balance left on the compensating transaction:
+//   - refunding a debit closes what is still open on it, any excess works like a credit and discharges other debits
+//   - reversing a credit takes back its unused part, the part already used to discharge debits becomes a new open debit
+func (s *txnSrv) offset(ctx context.Context, original *models.Transaction, amount decimal.Decimal) (decimal.Decimal, error) {
+	var settled decimal.Decimal
+	if amount.IsPositive() {
+		settled = decimal.Min(amount, original.Balance.Neg())
+	} else {
+		settled = decimal.Max(amount, original.Balance.Neg())
+	}
+	if !settled.IsZero() {
+		original.Balance = money.New(original.Balance.Add(settled))
+		if err := s.transactionRepo.UpdateBalance(ctx, original); err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	left := amount.Sub(settled)
+	if left.IsPositive() {
+		return s.discharge(ctx, original.AccountID, left)
+	}
+	return left, nil
+}
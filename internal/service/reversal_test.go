@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReverseTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Operation: mockOperationRepo, Ledger: acceptJournal(ctrl)})
+
+	interest := &models.OperationType{ID: 7, EntryType: models.DebitEntry, Code: models.OpCodeInterest}
+	mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(interest, nil).AnyTimes()
+	mockOperationRepo.EXPECT().GetByID(gomock.Any(), gomock.Any(), false).Return(&models.OperationType{ID: 1, EntryType: models.DebitEntry}, nil).AnyTimes()
+
+	// lockAccount expects the account row to be locked and its credit limit to be updated
+	lockAccount := func(limit float64) *models.Account {
+		account := &models.Account{ID: 1, AvailableCreditLimit: creditLimit(limit)}
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil)
+		return account
+	}
+	// lockOriginal expects the original transaction to be locked after its account, see lockAccountOnly or lockAccount
+	lockOriginal := func(original *models.Transaction) {
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), original.ID, false).Return(original, nil)
+		mockTransactionRepo.EXPECT().GetByIDForUpdate(gomock.Any(), original.ID).Return(original, nil)
+	}
+	lockAccountOnly := func() {
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1}, nil)
+	}
+	createReturnsInput := func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+		return txn, nil
+	}
+
+	t.Run("full reversal of an open debit", func(t *testing.T) {
+		original := &models.Transaction{ID: 5, AccountID: 1, Currency: models.DefaultCurrency, OperationTypeID: 1, Status: models.TxnStatusCompleted,
+			Amount: amount(-100), Balance: amount(-100)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		mockTransactionRepo.EXPECT().GetRefundedAmount(gomock.Any(), int64(5)).Return(decimal.Zero, nil)
+		account := lockAccount(0)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().UpdateStatus(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(createReturnsInput)
+
+		reversal, err := service.ReverseTransaction(context.Background(), 5, &api.ReverseTransactionRequest{})
+		assert.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(100).Equal(reversal.Amount.Decimal))
+		assert.True(t, decimal.Zero.Equal(reversal.Balance.Decimal))
+		assert.Equal(t, int64(5), *reversal.ReversedTxnID)
+		assert.Equal(t, int64(1), reversal.OperationTypeID)
+		assert.Equal(t, models.TxnStatusReversed, original.Status)
+		assert.True(t, decimal.Zero.Equal(original.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(100).Equal(account.AvailableCreditLimit.Decimal))
+	})
+
+	t.Run("partial refund of a discharged debit", func(t *testing.T) {
+		original := &models.Transaction{ID: 6, AccountID: 1, Currency: models.DefaultCurrency, OperationTypeID: 1, Status: models.TxnStatusPartiallyRefunded,
+			Amount: amount(-100), Balance: amount(0)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		mockTransactionRepo.EXPECT().GetRefundedAmount(gomock.Any(), int64(6)).Return(decimal.NewFromFloat(40), nil)
+		lockAccount(0)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return(nil, nil)
+		mockTransactionRepo.EXPECT().UpdateStatus(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(createReturnsInput)
+
+		reversal, err := service.ReverseTransaction(context.Background(), 6, &api.ReverseTransactionRequest{Amount: amount(25)})
+		assert.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(25).Equal(reversal.Amount.Decimal))
+		// the debit was already paid, so the refund stays available as a credit
+		assert.True(t, decimal.NewFromFloat(25).Equal(reversal.Balance.Decimal))
+		assert.Equal(t, models.TxnStatusPartiallyRefunded, original.Status)
+	})
+
+	t.Run("reversal of a partially used credit", func(t *testing.T) {
+		original := &models.Transaction{ID: 7, AccountID: 1, Currency: models.DefaultCurrency, OperationTypeID: 4, Status: models.TxnStatusCompleted,
+			Amount: amount(100), Balance: amount(40)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		mockTransactionRepo.EXPECT().GetRefundedAmount(gomock.Any(), int64(7)).Return(decimal.Zero, nil)
+		lockAccount(500)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().UpdateStatus(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(createReturnsInput)
+
+		reversal, err := service.ReverseTransaction(context.Background(), 7, &api.ReverseTransactionRequest{})
+		assert.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(-100).Equal(reversal.Amount.Decimal))
+		// the 60 used to discharge debits are owed again
+		assert.True(t, decimal.NewFromFloat(-60).Equal(reversal.Balance.Decimal))
+		assert.True(t, decimal.Zero.Equal(original.Balance.Decimal))
+		assert.Equal(t, models.TxnStatusReversed, original.Status)
+	})
+
+	t.Run("refund exceeding the amount left", func(t *testing.T) {
+		original := &models.Transaction{ID: 8, AccountID: 1, Currency: models.DefaultCurrency, Status: models.TxnStatusPartiallyRefunded, Amount: amount(-100)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		lockAccountOnly()
+		mockTransactionRepo.EXPECT().GetRefundedAmount(gomock.Any(), int64(8)).Return(decimal.NewFromFloat(80), nil)
+
+		reversal, err := service.ReverseTransaction(context.Background(), 8, &api.ReverseTransactionRequest{Amount: amount(20.01)})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, reversal)
+	})
+
+	t.Run("already reversed", func(t *testing.T) {
+		original := &models.Transaction{ID: 9, AccountID: 1, Status: models.TxnStatusReversed, Amount: amount(-100)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		lockAccountOnly()
+
+		reversal, err := service.ReverseTransaction(context.Background(), 9, &api.ReverseTransactionRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Nil(t, reversal)
+	})
+
+	t.Run("reversing a reversal", func(t *testing.T) {
+		reversedID := int64(9)
+		original := &models.Transaction{ID: 10, AccountID: 1, Status: models.TxnStatusCompleted, Amount: amount(100), ReversedTxnID: &reversedID}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		lockAccountOnly()
+
+		reversal, err := service.ReverseTransaction(context.Background(), 10, &api.ReverseTransactionRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, reversal)
+	})
+
+	t.Run("reversing a side of a transfer", func(t *testing.T) {
+		transferID := int64(4)
+		original := &models.Transaction{ID: 11, AccountID: 1, Status: models.TxnStatusCompleted, Amount: amount(-100), TransferID: &transferID}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		lockAccountOnly()
+
+		reversal, err := service.ReverseTransaction(context.Background(), 11, &api.ReverseTransactionRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrReverseTransfer, he.Message)
+		assert.Nil(t, reversal)
+	})
+
+	t.Run("waiving interest", func(t *testing.T) {
+		original := &models.Transaction{ID: 12, AccountID: 1, Currency: models.DefaultCurrency, OperationTypeID: 7, Status: models.TxnStatusCompleted,
+			Amount: amount(-3.5), Balance: amount(-3.5)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		mockTransactionRepo.EXPECT().GetRefundedAmount(gomock.Any(), int64(12)).Return(decimal.Zero, nil)
+		lockAccount(0)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().UpdateStatus(gomock.Any(), original).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(createReturnsInput)
+
+		reversal, err := service.ReverseTransaction(context.Background(), 12, &api.ReverseTransactionRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "3.5", reversal.Amount.String())
+		// booked back against the fees ledger account
+		assert.Equal(t, interest, reversal.OperationType)
+	})
+
+	t.Run("pending authorization", func(t *testing.T) {
+		original := &models.Transaction{ID: 11, AccountID: 1, Status: models.TxnStatusPending, Amount: amount(-100)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockOriginal(original)
+		lockAccountOnly()
+
+		reversal, err := service.ReverseTransaction(context.Background(), 11, &api.ReverseTransactionRequest{})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, reversal)
+	})
+}
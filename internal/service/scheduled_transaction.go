@@ -0,0 +1,292 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/cron"
+	"github.com/uptrace/bun"
+)
+
+// scheduledBatchSize is the number of due scheduled transactions booked per run of the scheduler
+const scheduledBatchSize = 100
+
+// CreateScheduledTransaction schedules a transaction booked later by RunScheduledTransactions, once at start_at or
+// recurring (daily, weekly, monthly or on a cron expression) until end_at or max_occurrences is reached
+// The account, operation type, amount and currency are checked now like CreateTransaction does, every occurrence is
+// still checked again when it is booked
+func (s *txnSrv) CreateScheduledTransaction(ctx context.Context, req *api.CreateScheduledTransactionRequest) (*models.ScheduledTransaction, error) {
+	frequency := models.ScheduleFrequency(strings.ToLower(req.Frequency))
+	if err := frequency.Validate(); err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidFrequency, err)
+	}
+	scheduled := &models.ScheduledTransaction{
+		AccountID:       req.AccountID,
+		OperationTypeID: req.OperationTypeID,
+		Amount:          req.Amount,
+		Frequency:       frequency,
+		CronExpression:  strings.TrimSpace(req.CronExpression),
+		MaxOccurrences:  req.MaxOccurrences,
+		Status:          models.ScheduleActive,
+	}
+	if (frequency == models.FrequencyCron) != (scheduled.CronExpression != "") {
+		return nil, api.BadRequestErr(api.ErrInvalidCronExpression, nil)
+	}
+	if !req.Amount.IsPositive() {
+		return nil, api.BadRequestErr(api.ErrScheduleAmount, nil)
+	}
+	if req.Currency != "" {
+		scheduled.Currency = models.Currency(strings.ToUpper(req.Currency))
+		if err := scheduled.Currency.Validate(); err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidCurrency, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	scheduled.StartAt = now
+	if req.StartAt != nil {
+		if req.StartAt.Before(now) {
+			return nil, api.BadRequestErr(api.ErrScheduleStartInPast, nil)
+		}
+		scheduled.StartAt = req.StartAt.UTC()
+	}
+	if req.EndAt != nil {
+		if !req.EndAt.After(scheduled.StartAt) {
+			return nil, api.BadRequestErr(api.ErrScheduleEndBeforeStart, nil)
+		}
+		end := req.EndAt.UTC()
+		scheduled.EndAt = &end
+	}
+	first := scheduled.StartAt
+	if frequency == models.FrequencyCron {
+		schedule, err := cron.Parse(scheduled.CronExpression)
+		if err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidCronExpression, err)
+		}
+		// start_at itself is the first occurrence when it matches
+		if first = schedule.Next(scheduled.StartAt.Add(-time.Nanosecond)); first.IsZero() || (scheduled.EndAt != nil && first.After(*scheduled.EndAt)) {
+			return nil, api.BadRequestErr(api.ErrScheduleNeverRuns, nil)
+		}
+	}
+	scheduled.NextRunAt = &first
+
+	operation, err := s.operationRepo.GetByID(ctx, req.OperationTypeID, false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, api.BadRequestErr(api.ErrOpTypeNotFound, nil)
+		}
+		return nil, err
+	}
+	if !operation.Active {
+		return nil, api.UnprocessableEntityErr(api.ErrOpTypeInactive, nil)
+	}
+	if operation.Code != "" {
+		return nil, api.UnprocessableEntityErr(api.ErrOpTypeReserved, nil)
+	}
+	account, err := s.accountRepo.GetByID(ctx, req.AccountID, false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, api.BadRequestErr(api.ErrAccountNotFound, nil)
+		}
+		return nil, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return nil, api.UnprocessableEntityErr(api.ErrAccountClosed, nil)
+	}
+	if !cmp.Or(scheduled.Currency, account.Currency).Fits(req.Amount.Decimal) {
+		return nil, api.BadRequestErr(api.ErrAmountPrecision, nil)
+	}
+	slog.Debug("CreateScheduledTransaction", "account", account.ID, "amount", req.Amount, "frequency", frequency, "next_run_at", first)
+
+	return s.scheduledRepo.Create(ctx, scheduled)
+}
+
+// GetScheduledTransaction fetches a scheduled transaction along with the result of every occurrence booked
+func (s *txnSrv) GetScheduledTransaction(ctx context.Context, id int64) (*models.ScheduledTransaction, error) {
+	return s.scheduledRepo.GetByID(ctx, id)
+}
+
+// CancelScheduledTransaction stops an active scheduled transaction, the occurrences already booked are kept
+func (s *txnSrv) CancelScheduledTransaction(ctx context.Context, id int64) (*models.ScheduledTransaction, error) {
+	var scheduled *models.ScheduledTransaction
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var err error
+		if scheduled, err = s.scheduledRepo.GetByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if scheduled.Status != models.ScheduleActive {
+			return api.UnprocessableEntityErr(api.ErrScheduleNotActive, nil)
+		}
+		scheduled.Status = models.ScheduleCancelled
+		scheduled.NextRunAt = nil
+		return s.scheduledRepo.UpdateSchedule(ctx, scheduled)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// RunScheduledTransactions books the occurrences due at now of at most scheduledBatchSize scheduled transactions,
+// catching up the occurrences missed while the scheduler was not running: those late by more than maxLateness are
+// recorded as skipped without being booked, so a long downtime does not book them all back to back (0 books them all)
+// Each occurrence is booked through CreateTransaction in the same DB transaction that records its execution and moves
+// the schedule to the next occurrence, with the schedule row locked: a retry, or another server running the scheduler,
+// finds the occurrence already booked and books nothing more
+// An occurrence rejected like a request would be (e.g. insufficient credit limit) is recorded as failed and skipped,
+// any other error is logged and the occurrence is retried on the next run
+func (s *txnSrv) RunScheduledTransactions(ctx context.Context, now time.Time, maxLateness time.Duration) ([]*models.ScheduledExecution, error) {
+	due, err := s.scheduledRepo.GetDue(ctx, now, scheduledBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	var executions []*models.ScheduledExecution
+	for _, scheduled := range due {
+		for id := scheduled.ID; scheduled != nil; {
+			var execution *models.ScheduledExecution
+			if scheduled, execution, err = s.runOccurrence(ctx, id, now, maxLateness); err != nil {
+				slog.Error("RunScheduledTransactions", "scheduled_transaction", id, "error", err)
+				break
+			}
+			if execution != nil {
+				executions = append(executions, execution)
+			}
+		}
+	}
+	return executions, nil
+}
+
+// runOccurrence books the next occurrence of a scheduled transaction if it is due at now, or skips it when it is late by
+// more than maxLateness
+// Returns the schedule when its following occurrence is due at now as well, nil when there is nothing left to book
+func (s *txnSrv) runOccurrence(ctx context.Context, id int64, now time.Time, maxLateness time.Duration) (*models.ScheduledTransaction, *models.ScheduledExecution, error) {
+	var scheduled *models.ScheduledTransaction
+	var execution *models.ScheduledExecution
+	var occurrence time.Time
+	var rejected string
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var err error
+		if scheduled, err = s.scheduledRepo.GetByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if !isDue(scheduled, now) {
+			return nil
+		}
+		occurrence = *scheduled.NextRunAt
+		if maxLateness > 0 && now.Sub(occurrence) > maxLateness {
+			slog.Debug("runOccurrence", "scheduled_transaction", id, "scheduled_for", occurrence, "skipped", now.Sub(occurrence))
+			execution, err = s.recordOccurrence(ctx, scheduled, &models.ScheduledExecution{ScheduledFor: occurrence, Status: models.ExecutionSkipped})
+			return err
+		}
+		txn, err := s.CreateTransaction(ctx, &api.CreateTransactionRequest{
+			AccountID:       scheduled.AccountID,
+			OperationTypeID: scheduled.OperationTypeID,
+			Amount:          scheduled.Amount,
+			Currency:        scheduled.Currency.String(),
+		})
+		if err != nil {
+			var isClientErr bool
+			rejected, isClientErr = api.ClientErrMessage(err)
+			if !isClientErr {
+				return err
+			}
+			return errRejected
+		}
+		execution, err = s.recordOccurrence(ctx, scheduled, &models.ScheduledExecution{ScheduledFor: occurrence, Status: models.ExecutionSucceeded, TransactionID: &txn.ID})
+		return err
+	})
+	if errors.Is(err, errRejected) {
+		// the rejected transaction was rolled back along with it, the failure is recorded on its own
+		err = s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+			var err error
+			if scheduled, err = s.scheduledRepo.GetByIDForUpdate(ctx, id); err != nil {
+				return err
+			}
+			// booked by another run meanwhile
+			if !isDue(scheduled, now) || !scheduled.NextRunAt.Equal(occurrence) {
+				return nil
+			}
+			slog.Debug("runOccurrence", "scheduled_transaction", id, "scheduled_for", occurrence, "rejected", rejected)
+			execution, err = s.recordOccurrence(ctx, scheduled, &models.ScheduledExecution{ScheduledFor: occurrence, Status: models.ExecutionFailed, Error: truncate(rejected, 255)})
+			return err
+		})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isDue(scheduled, now) {
+		return nil, execution, nil
+	}
+	return scheduled, execution, nil
+}
+
+// errRejected rolls back the DB transaction of an occurrence rejected by CreateTransaction
+var errRejected = errors.New("scheduled transaction rejected")
+
+func isDue(scheduled *models.ScheduledTransaction, now time.Time) bool {
+	return scheduled.Status == models.ScheduleActive && scheduled.NextRunAt != nil && !scheduled.NextRunAt.After(now)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+// recordOccurrence records the execution of the next occurrence of a locked scheduled transaction and moves it to the
+// following occurrence, completing it when there is none
+func (s *txnSrv) recordOccurrence(ctx context.Context, scheduled *models.ScheduledTransaction, execution *models.ScheduledExecution) (*models.ScheduledExecution, error) {
+	execution.ScheduledTransactionID = scheduled.ID
+	execution, err := s.scheduledRepo.CreateExecution(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled.Occurrences++
+	next := nextOccurrence(scheduled, execution.ScheduledFor)
+	switch {
+	case next.IsZero(),
+		scheduled.MaxOccurrences != nil && scheduled.Occurrences >= *scheduled.MaxOccurrences,
+		scheduled.EndAt != nil && next.After(*scheduled.EndAt):
+		scheduled.Status = models.ScheduleCompleted
+		scheduled.NextRunAt = nil
+	default:
+		scheduled.NextRunAt = &next
+	}
+	if err := s.scheduledRepo.UpdateSchedule(ctx, scheduled); err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+// nextOccurrence returns the occurrence of a scheduled transaction following previous, the zero time when there is none
+// Daily, weekly and monthly occurrences are counted from start_at, so a monthly schedule starting on the 31st is booked
+// on the last day of shorter months and back on the 31st afterwards
+func nextOccurrence(scheduled *models.ScheduledTransaction, previous time.Time) time.Time {
+	start := scheduled.StartAt.UTC()
+	switch scheduled.Frequency {
+	case models.FrequencyDaily:
+		return start.AddDate(0, 0, scheduled.Occurrences)
+	case models.FrequencyWeekly:
+		return start.AddDate(0, 0, 7*scheduled.Occurrences)
+	case models.FrequencyMonthly:
+		day := addMonths(start, scheduled.Occurrences)
+		return time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), time.UTC)
+	case models.FrequencyCron:
+		schedule, err := cron.Parse(scheduled.CronExpression)
+		if err != nil {
+			return time.Time{}
+		}
+		return schedule.Next(previous)
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	mockScheduledRepo := mockRepo.NewMockScheduledTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Operation: mockOperationRepo, ScheduledTransaction: mockScheduledRepo})
+
+	purchase := &models.OperationType{ID: 1, EntryType: models.DebitEntry, Active: true}
+	account := &models.Account{ID: 7, Currency: models.DefaultCurrency, Status: models.AccountStatusActive}
+	tomorrow := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+
+	// expectCreate expects the scheduled transaction to be stored with ID 4
+	expectCreate := func() {
+		mockScheduledRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, scheduled *models.ScheduledTransaction) (*models.ScheduledTransaction, error) {
+				scheduled.ID = 4
+				return scheduled, nil
+			})
+	}
+
+	t.Run("monthly from start_at", func(t *testing.T) {
+		start := tomorrow.Add(10 * time.Hour)
+		end := start.AddDate(1, 0, 0)
+		maxOccurrences := 6
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(purchase, nil)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(account, nil)
+		expectCreate()
+
+		scheduled, err := service.CreateScheduledTransaction(context.Background(), &api.CreateScheduledTransactionRequest{
+			AccountID: 7, OperationTypeID: 1, Amount: amount(50), Frequency: "Monthly", StartAt: &start, EndAt: &end, MaxOccurrences: &maxOccurrences,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), scheduled.ID)
+		assert.Equal(t, models.FrequencyMonthly, scheduled.Frequency)
+		assert.Equal(t, models.ScheduleActive, scheduled.Status)
+		assert.Equal(t, start, *scheduled.NextRunAt)
+		assert.Equal(t, end, *scheduled.EndAt)
+		assert.Equal(t, 6, *scheduled.MaxOccurrences)
+	})
+
+	t.Run("first match of the cron expression from start_at", func(t *testing.T) {
+		start := tomorrow.Add(8*time.Hour + 30*time.Minute)
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(purchase, nil)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(account, nil)
+		expectCreate()
+
+		scheduled, err := service.CreateScheduledTransaction(context.Background(), &api.CreateScheduledTransactionRequest{
+			AccountID: 7, OperationTypeID: 1, Amount: amount(50), Frequency: "cron", CronExpression: "0 9 * * *", StartAt: &start,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tomorrow.Add(9*time.Hour), *scheduled.NextRunAt)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		past := time.Now().UTC().Add(-time.Minute)
+		start := tomorrow.Add(10 * time.Hour)
+		end := start.Add(time.Hour)
+		tests := []struct {
+			name     string
+			req      *api.CreateScheduledTransactionRequest
+			expected string
+		}{
+			{"unknown frequency", &api.CreateScheduledTransactionRequest{Frequency: "yearly", Amount: amount(50)}, api.ErrInvalidFrequency},
+			{"cron frequency without expression", &api.CreateScheduledTransactionRequest{Frequency: "cron", Amount: amount(50)}, api.ErrInvalidCronExpression},
+			{"expression without cron frequency", &api.CreateScheduledTransactionRequest{Frequency: "daily", CronExpression: "0 9 * * *", Amount: amount(50)}, api.ErrInvalidCronExpression},
+			{"invalid cron expression", &api.CreateScheduledTransactionRequest{Frequency: "cron", CronExpression: "0 25 * * *", Amount: amount(50)}, api.ErrInvalidCronExpression},
+			{"negative amount", &api.CreateScheduledTransactionRequest{Frequency: "once", Amount: amount(-50)}, api.ErrScheduleAmount},
+			{"start in the past", &api.CreateScheduledTransactionRequest{Frequency: "once", Amount: amount(50), StartAt: &past}, api.ErrScheduleStartInPast},
+			{"end before start", &api.CreateScheduledTransactionRequest{Frequency: "daily", Amount: amount(50), StartAt: &end, EndAt: &start}, api.ErrScheduleEndBeforeStart},
+			{"no cron match before end", &api.CreateScheduledTransactionRequest{Frequency: "cron", CronExpression: "0 9 * * *", Amount: amount(50), StartAt: &start, EndAt: &end}, api.ErrScheduleNeverRuns},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				scheduled, err := service.CreateScheduledTransaction(context.Background(), tt.req)
+				he, ok := err.(*echo.HTTPError)
+				if assert.True(t, ok) {
+					assert.Equal(t, http.StatusBadRequest, he.Code)
+					assert.Equal(t, tt.expected, he.Message)
+				}
+				assert.Nil(t, scheduled)
+			})
+		}
+	})
+
+	t.Run("operation type not found", func(t *testing.T) {
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(9), false).Return(nil, sql.ErrNoRows)
+
+		scheduled, err := service.CreateScheduledTransaction(context.Background(), &api.CreateScheduledTransactionRequest{
+			AccountID: 7, OperationTypeID: 9, Amount: amount(50), Frequency: "once",
+		})
+		he, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, api.ErrOpTypeNotFound, he.Message)
+		}
+		assert.Nil(t, scheduled)
+	})
+
+	t.Run("closed account", func(t *testing.T) {
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(purchase, nil)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(8), false).Return(&models.Account{ID: 8, Currency: models.DefaultCurrency, Status: models.AccountStatusClosed}, nil)
+
+		scheduled, err := service.CreateScheduledTransaction(context.Background(), &api.CreateScheduledTransactionRequest{
+			AccountID: 8, OperationTypeID: 1, Amount: amount(50), Frequency: "weekly",
+		})
+		he, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+			assert.Equal(t, api.ErrAccountClosed, he.Message)
+		}
+		assert.Nil(t, scheduled)
+	})
+}
+
+func TestRunScheduledTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	mockScheduledRepo := mockRepo.NewMockScheduledTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Operation: mockOperationRepo, Ledger: acceptJournal(ctrl), ScheduledTransaction: mockScheduledRepo})
+
+	purchase := &models.OperationType{ID: 1, EntryType: models.DebitEntry, Active: true}
+	mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(purchase, nil).AnyTimes()
+	mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(purchase, nil).AnyTimes()
+	mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx).AnyTimes()
+
+	// schedule builds an active schedule of a 50 purchase on account 7 whose next occurrence is start
+	schedule := func(id int64, frequency models.ScheduleFrequency, start time.Time) *models.ScheduledTransaction {
+		return &models.ScheduledTransaction{
+			ID: id, AccountID: 7, OperationTypeID: 1, Amount: amount(50), Frequency: frequency,
+			StartAt: start, NextRunAt: &start, Status: models.ScheduleActive,
+		}
+	}
+	// expectPurchase expects a purchase to be booked on the account, with ID 20
+	expectPurchase := func(account *models.Account) {
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				assert.Equal(t, "-50", txn.Amount.String())
+				txn.ID = 20
+				return txn, nil
+			})
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil)
+	}
+	// expectRecord expects the execution to be stored and the schedule to be moved to its next occurrence
+	expectRecord := func(scheduled *models.ScheduledTransaction) {
+		mockScheduledRepo.EXPECT().CreateExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, execution *models.ScheduledExecution) (*models.ScheduledExecution, error) {
+				return execution, nil
+			})
+		mockScheduledRepo.EXPECT().UpdateSchedule(gomock.Any(), scheduled).Return(nil)
+	}
+
+	t.Run("monthly occurrence booked and moved to the end of the next month", func(t *testing.T) {
+		start := time.Date(2025, time.January, 31, 10, 0, 0, 0, time.UTC)
+		now := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+		scheduled := schedule(4, models.FrequencyMonthly, start)
+		account := &models.Account{ID: 7, Currency: models.DefaultCurrency, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(100)}
+
+		mockScheduledRepo.EXPECT().GetDue(gomock.Any(), now, scheduledBatchSize).Return([]*models.ScheduledTransaction{scheduled}, nil)
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(4)).Return(scheduled, nil)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(account, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(account, nil)
+		expectPurchase(account)
+		expectRecord(scheduled)
+
+		executions, err := service.RunScheduledTransactions(context.Background(), now, 0)
+		assert.NoError(t, err)
+		if assert.Len(t, executions, 1) {
+			assert.Equal(t, int64(4), executions[0].ScheduledTransactionID)
+			assert.Equal(t, start, executions[0].ScheduledFor)
+			assert.Equal(t, models.ExecutionSucceeded, executions[0].Status)
+			assert.Equal(t, int64(20), *executions[0].TransactionID)
+		}
+		assert.Equal(t, 1, scheduled.Occurrences)
+		assert.Equal(t, models.ScheduleActive, scheduled.Status)
+		assert.Equal(t, time.Date(2025, time.February, 28, 10, 0, 0, 0, time.UTC), *scheduled.NextRunAt)
+		assert.Equal(t, "50", account.AvailableCreditLimit.String())
+	})
+
+	t.Run("occurrence already booked by another run", func(t *testing.T) {
+		start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+		now := start.Add(time.Minute)
+		next := start.AddDate(0, 0, 1)
+		booked := schedule(5, models.FrequencyDaily, start)
+		booked.NextRunAt, booked.Occurrences = &next, 1
+
+		mockScheduledRepo.EXPECT().GetDue(gomock.Any(), now, scheduledBatchSize).Return([]*models.ScheduledTransaction{schedule(5, models.FrequencyDaily, start)}, nil)
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(5)).Return(booked, nil)
+
+		executions, err := service.RunScheduledTransactions(context.Background(), now, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, executions)
+	})
+
+	t.Run("rejected occurrence recorded as failed, completing the schedule", func(t *testing.T) {
+		start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+		now := start.Add(time.Minute)
+		maxOccurrences := 1
+		scheduled := schedule(6, models.FrequencyWeekly, start)
+		scheduled.MaxOccurrences = &maxOccurrences
+		account := &models.Account{ID: 7, Currency: models.DefaultCurrency, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(10)}
+
+		mockScheduledRepo.EXPECT().GetDue(gomock.Any(), now, scheduledBatchSize).Return([]*models.ScheduledTransaction{scheduled}, nil)
+		// locked again to record the failure once the rejected transaction is rolled back
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(6)).Return(scheduled, nil).Times(2)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(account, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(account, nil)
+		expectRecord(scheduled)
+
+		executions, err := service.RunScheduledTransactions(context.Background(), now, 0)
+		assert.NoError(t, err)
+		if assert.Len(t, executions, 1) {
+			assert.Equal(t, models.ExecutionFailed, executions[0].Status)
+			assert.Nil(t, executions[0].TransactionID)
+			assert.Equal(t, api.ErrInsufficientLimit, executions[0].Error)
+		}
+		assert.Equal(t, 1, scheduled.Occurrences)
+		assert.Equal(t, models.ScheduleCompleted, scheduled.Status)
+		assert.Nil(t, scheduled.NextRunAt)
+	})
+
+	t.Run("missed daily occurrences all caught up without a max lateness", func(t *testing.T) {
+		start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+		now := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 0, 1)
+		scheduled := schedule(7, models.FrequencyDaily, start)
+		scheduled.EndAt = &end
+		account := &models.Account{ID: 7, Currency: models.DefaultCurrency, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(100)}
+
+		mockScheduledRepo.EXPECT().GetDue(gomock.Any(), now, scheduledBatchSize).Return([]*models.ScheduledTransaction{scheduled}, nil)
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(scheduled, nil).Times(2)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(account, nil).Times(2)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(account, nil).Times(2)
+		expectPurchase(account)
+		expectPurchase(account)
+		expectRecord(scheduled)
+		expectRecord(scheduled)
+
+		executions, err := service.RunScheduledTransactions(context.Background(), now, 0)
+		assert.NoError(t, err)
+		if assert.Len(t, executions, 2) {
+			assert.Equal(t, start, executions[0].ScheduledFor)
+			assert.Equal(t, end, executions[1].ScheduledFor)
+		}
+		assert.Equal(t, 2, scheduled.Occurrences)
+		assert.Equal(t, models.ScheduleCompleted, scheduled.Status)
+		assert.Nil(t, scheduled.NextRunAt)
+	})
+
+	t.Run("occurrences late by more than the max lateness skipped", func(t *testing.T) {
+		start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+		now := time.Date(2025, time.March, 3, 9, 30, 0, 0, time.UTC)
+		scheduled := schedule(8, models.FrequencyDaily, start)
+		account := &models.Account{ID: 7, Currency: models.DefaultCurrency, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(100)}
+
+		mockScheduledRepo.EXPECT().GetDue(gomock.Any(), now, scheduledBatchSize).Return([]*models.ScheduledTransaction{scheduled}, nil)
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(8)).Return(scheduled, nil).Times(3)
+		// only the occurrence of the day, 30 minutes late, is booked
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(7), false).Return(account, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(account, nil)
+		expectPurchase(account)
+		expectRecord(scheduled)
+		expectRecord(scheduled)
+		expectRecord(scheduled)
+
+		executions, err := service.RunScheduledTransactions(context.Background(), now, time.Hour)
+		assert.NoError(t, err)
+		if assert.Len(t, executions, 3) {
+			assert.Equal(t, models.ExecutionSkipped, executions[0].Status)
+			assert.Nil(t, executions[0].TransactionID)
+			assert.Equal(t, models.ExecutionSkipped, executions[1].Status)
+			assert.Equal(t, models.ExecutionSucceeded, executions[2].Status)
+			assert.Equal(t, time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC), executions[2].ScheduledFor)
+		}
+		assert.Equal(t, 3, scheduled.Occurrences)
+		assert.Equal(t, time.Date(2025, time.March, 4, 9, 0, 0, 0, time.UTC), *scheduled.NextRunAt)
+	})
+}
+
+func TestCancelScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockScheduledRepo := mockRepo.NewMockScheduledTransaction(ctrl)
+	service := NewTransactionService(Repos{Transaction: mockTransactionRepo, ScheduledTransaction: mockScheduledRepo})
+	mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx).AnyTimes()
+
+	t.Run("active schedule", func(t *testing.T) {
+		next := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+		scheduled := &models.ScheduledTransaction{ID: 4, Status: models.ScheduleActive, NextRunAt: &next}
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(4)).Return(scheduled, nil)
+		mockScheduledRepo.EXPECT().UpdateSchedule(gomock.Any(), scheduled).Return(nil)
+
+		cancelled, err := service.CancelScheduledTransaction(context.Background(), 4)
+		assert.NoError(t, err)
+		assert.Equal(t, models.ScheduleCancelled, cancelled.Status)
+		assert.Nil(t, cancelled.NextRunAt)
+	})
+
+	t.Run("completed schedule", func(t *testing.T) {
+		mockScheduledRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(5)).Return(&models.ScheduledTransaction{ID: 5, Status: models.ScheduleCompleted}, nil)
+
+		cancelled, err := service.CancelScheduledTransaction(context.Background(), 5)
+		he, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+			assert.Equal(t, api.ErrScheduleNotActive, he.Message)
+		}
+		assert.Nil(t, cancelled)
+	})
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/uptrace/bun"
+)
+
+// statementBatchSize is the number of accounts read per page while closing billing cycles
+const statementBatchSize = 100
+
+// CloseStatements generates the statement of every billing cycle ended at or before asOf (capped to now) that has none yet,
+// account by account. Running it again, or concurrently, does not generate a cycle twice
+// A cycle missed by previous runs is closed as well, each one in its own statement
+func (s *txnSrv) CloseStatements(ctx context.Context, asOf time.Time) ([]*models.Statement, error) {
+	if now := time.Now().UTC(); asOf.After(now) {
+		asOf = now
+	}
+	var statements []*models.Statement
+	var cursor *repo.Cursor
+	for {
+		accounts, next, err := s.accountRepo.List(ctx, repo.NewListQuery().Page(cursor, statementBatchSize))
+		if err != nil {
+			return statements, err
+		}
+		for _, account := range accounts {
+			closed, err := s.closeCycles(ctx, account.ID, asOf)
+			if err != nil {
+				return statements, err
+			}
+			statements = append(statements, closed...)
+		}
+		if next == nil {
+			return statements, nil
+		}
+		cursor = next
+	}
+}
+
+// closeCycles closes the cycles of an account ended at or before asOf, in one DB transaction with the account row locked
+// Transactions are booked with the account row locked too, so none can be added to a cycle while it is closed
+// A closed account gets the statement of the cycle it was closed in and none after
+func (s *txnSrv) closeCycles(ctx context.Context, accountID int64, asOf time.Time) ([]*models.Statement, error) {
+	var statements []*models.Statement
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		account, err := s.accountRepo.GetByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		previous, err := s.statementRepo.GetLatest(ctx, accountID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		start, opening := account.CreatedAt, models.Money{}
+		if previous != nil {
+			start, opening = previous.PeriodEnd, previous.ClosingBalance
+		}
+
+		for end := account.NextClosing(start); !end.After(asOf); end = end.AddDate(0, 1, 0) {
+			if account.Status == models.AccountStatusClosed && account.StatusChangedAt != nil && !start.Before(*account.StatusChangedAt) {
+				break
+			}
+			statement, err := s.statementRepo.Create(ctx, &models.Statement{
+				AccountID:      account.ID,
+				Currency:       account.Currency,
+				PeriodStart:    start,
+				PeriodEnd:      end,
+				DueDate:        end.AddDate(0, 0, account.DueDays),
+				OpeningBalance: opening,
+			})
+			if err != nil {
+				return err
+			}
+			frozen, err := s.transactionRepo.FreezeForStatement(ctx, statement)
+			if err != nil {
+				return err
+			}
+			statement.Close(frozen)
+			if err := s.statementRepo.UpdateTotals(ctx, statement); err != nil {
+				return err
+			}
+			slog.Debug("closeCycles", "account", account.ID, "statement", statement.ID, "period_end", end, "closing_balance", statement.ClosingBalance)
+
+			statements = append(statements, statement)
+			start, opening = end, statement.ClosingBalance
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// GetAccountStatements fetches one page of the statements of an account, oldest first
+// Fetches the account first so that a missing account results in a 404 instead of an empty page
+func (s *txnSrv) GetAccountStatements(ctx context.Context, accountID int64, req *api.ListStatementsRequest) (*api.Page[*models.Statement], error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID, false); err != nil {
+		return nil, err
+	}
+	cursor, err := repo.DecodeCursor(req.Cursor, "")
+	if err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidCursor, err)
+	}
+
+	statements, next, err := s.statementRepo.GetByAccountID(ctx, accountID, repo.NewListQuery().Page(cursor, req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &api.Page[*models.Statement]{Items: statements, NextCursor: next.Encode()}, nil
+}
+
+// GetStatement fetches a statement along with the transactions frozen into it
+func (s *txnSrv) GetStatement(ctx context.Context, id int64) (*models.Statement, error) {
+	return s.statementRepo.GetByID(ctx, id)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCloseStatements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockStatementRepo := mockRepo.NewMockStatement(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Statement: mockStatementRepo})
+
+	asOf := time.Date(2025, time.April, 20, 12, 0, 0, 0, time.UTC)
+
+	// closeCycles expects the account to be listed and locked, and the cycles to be created and closed with the given frozen totals
+	closeCycles := func(account *models.Account, previous *models.Statement, frozen ...*models.Balance) {
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*models.Account{account}, nil, nil)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), account.ID).Return(account, nil)
+		if previous != nil {
+			mockStatementRepo.EXPECT().GetLatest(gomock.Any(), account.ID).Return(previous, nil)
+		} else {
+			mockStatementRepo.EXPECT().GetLatest(gomock.Any(), account.ID).Return(nil, sql.ErrNoRows)
+		}
+		for i, totals := range frozen {
+			mockStatementRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, statement *models.Statement) (*models.Statement, error) {
+					statement.ID = int64(i + 1)
+					return statement, nil
+				})
+			mockTransactionRepo.EXPECT().FreezeForStatement(gomock.Any(), gomock.Any()).Return(totals, nil)
+			mockStatementRepo.EXPECT().UpdateTotals(gomock.Any(), gomock.Any()).Return(nil)
+		}
+	}
+
+	t.Run("closes every cycle missed since the account was opened", func(t *testing.T) {
+		account := &models.Account{ID: 1, Currency: models.DefaultCurrency, ClosingDay: 5, DueDays: 10,
+			CreatedAt: time.Date(2025, time.February, 10, 9, 0, 0, 0, time.UTC)}
+		closeCycles(account, nil,
+			&models.Balance{TotalCredits: decimal.NewFromInt(30), TotalDebits: decimal.RequireFromString("-100.01")},
+			&models.Balance{TotalCredits: decimal.NewFromInt(80)},
+		)
+
+		statements, err := service.CloseStatements(context.Background(), asOf)
+		assert.NoError(t, err)
+		if assert.Len(t, statements, 2) {
+			march, april := statements[0], statements[1]
+			assert.Equal(t, account.CreatedAt, march.PeriodStart)
+			assert.Equal(t, time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC), march.PeriodEnd)
+			assert.Equal(t, time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), march.DueDate)
+			assert.True(t, march.OpeningBalance.IsZero())
+			assert.Equal(t, "100.01", march.TotalDebits.String())
+			assert.Equal(t, "-70.01", march.ClosingBalance.String())
+			// 15% of 70.01 rounded up to the cent
+			assert.Equal(t, "10.51", march.MinimumPayment.String())
+
+			assert.Equal(t, march.PeriodEnd, april.PeriodStart)
+			assert.Equal(t, time.Date(2025, time.April, 5, 0, 0, 0, 0, time.UTC), april.PeriodEnd)
+			assert.Equal(t, "-70.01", april.OpeningBalance.String())
+			assert.Equal(t, "9.99", april.ClosingBalance.String())
+			assert.True(t, april.MinimumPayment.IsZero())
+		}
+	})
+
+	t.Run("nothing to close until the next closing day", func(t *testing.T) {
+		account := &models.Account{ID: 2, ClosingDay: 5}
+		previous := &models.Statement{ID: 7, AccountID: 2, PeriodEnd: time.Date(2025, time.April, 5, 0, 0, 0, 0, time.UTC), ClosingBalance: amount(-10)}
+		closeCycles(account, previous)
+
+		statements, err := service.CloseStatements(context.Background(), asOf)
+		assert.NoError(t, err)
+		assert.Empty(t, statements)
+	})
+
+	t.Run("closed account gets the statement of its last cycle only", func(t *testing.T) {
+		closedAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+		account := &models.Account{ID: 3, Currency: models.DefaultCurrency, ClosingDay: 20, DueDays: 10, Status: models.AccountStatusClosed, StatusChangedAt: &closedAt}
+		previous := &models.Statement{ID: 8, AccountID: 3, PeriodEnd: time.Date(2025, time.February, 20, 0, 0, 0, 0, time.UTC)}
+		closeCycles(account, previous, &models.Balance{})
+
+		statements, err := service.CloseStatements(context.Background(), asOf)
+		assert.NoError(t, err)
+		if assert.Len(t, statements, 1) {
+			assert.Equal(t, time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC), statements[0].PeriodEnd)
+		}
+	})
+
+	t.Run("pages through the accounts", func(t *testing.T) {
+		account := &models.Account{ID: 4, ClosingDay: 1}
+		previous := &models.Statement{AccountID: 4, PeriodEnd: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)}
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*models.Account{account}, &repo.Cursor{ID: 4}, nil)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), account.ID).Return(account, nil)
+		mockStatementRepo.EXPECT().GetLatest(gomock.Any(), account.ID).Return(previous, nil)
+		closeCycles(&models.Account{ID: 5, ClosingDay: 1}, previous)
+
+		statements, err := service.CloseStatements(context.Background(), asOf)
+		assert.NoError(t, err)
+		assert.Empty(t, statements)
+	})
+}
+
+func TestGetAccountStatements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockStatementRepo := mockRepo.NewMockStatement(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Statement: mockStatementRepo})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expected := []*models.Statement{{ID: 1, AccountID: 1}}
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1}, nil)
+		mockStatementRepo.EXPECT().GetByAccountID(gomock.Any(), int64(1), gomock.Any()).Return(expected, nil, nil)
+
+		page, err := service.GetAccountStatements(context.Background(), 1, &api.ListStatementsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page.Items)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(nil, sql.ErrNoRows)
+
+		page, err := service.GetAccountStatements(context.Background(), 2, &api.ListStatementsRequest{})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, page)
+	})
+}
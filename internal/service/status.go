@@ -0,0 +1,34 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+)
+
+// txnTransitions is the state machine of a transaction status, every status change has to be listed here
+//   - pending is an authorization holding the credit limit, it is either captured (completed) or voided (failed)
+//   - completed and partially refunded transactions can be refunded, up to fully reversed
+//   - failed and reversed are final
+var txnTransitions = map[models.TxnStatus][]models.TxnStatus{
+	models.TxnStatusPending:           {models.TxnStatusCompleted, models.TxnStatusFailed},
+	models.TxnStatusCompleted:         {models.TxnStatusPartiallyRefunded, models.TxnStatusReversed},
+	models.TxnStatusPartiallyRefunded: {models.TxnStatusPartiallyRefunded, models.TxnStatusReversed},
+}
+
+// accountTransitions is the state machine of an account status
+//   - blocked accounts can be reactivated, closed is final
+var accountTransitions = map[models.AccountStatus][]models.AccountStatus{
+	models.AccountStatusActive:  {models.AccountStatusBlocked, models.AccountStatusClosed},
+	models.AccountStatusBlocked: {models.AccountStatusActive, models.AccountStatusClosed},
+}
+
+// canTransition tells whether a transaction can move from one status to the other
+func canTransition(from, to models.TxnStatus) bool {
+	return slices.Contains(txnTransitions[from], to)
+}
+
+// canTransitionAccount tells whether an account can move from one status to the other
+func canTransitionAccount(from, to models.AccountStatus) bool {
+	return slices.Contains(accountTransitions[from], to)
+}
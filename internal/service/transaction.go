@@ -1,38 +1,106 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/akhiltak/pismo-api/internal/storage/models"
 	"github.com/akhiltak/pismo-api/internal/storage/repo"
 	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
 )
 
 type TransactionService interface {
 	CreateAccount(context.Context, *api.CreateAccountRequest) (*models.Account, error)
+	GetAccounts(context.Context, *api.ListAccountsRequest) (*api.Page[*models.Account], error)
 	GetAccountByID(context.Context, int64) (*models.Account, error)
+	UpdateAccount(context.Context, int64, *api.UpdateAccountRequest) (*models.Account, error)
+	GetBalance(context.Context, int64) (*api.AccountBalanceResponse, error)
+	CheckBalances(context.Context, *api.CheckBalancesRequest) (*api.CheckBalancesResponse, error)
+	CreateCustomer(context.Context, *api.CreateCustomerRequest) (*models.Customer, error)
+	GetCustomerAccounts(context.Context, int64, *api.ListCustomerAccountsRequest) (*api.Page[*models.Account], error)
+	EraseCustomer(context.Context, int64, *api.EraseCustomerRequest) (*models.Customer, error)
 	CreateTransaction(context.Context, *api.CreateTransactionRequest) (*models.Transaction, error)
-	GetTransactions(context.Context) ([]*models.Transaction, error)
+	GetTransactions(context.Context, *api.ListTransactionsRequest) (*api.Page[*models.Transaction], error)
+	GetAccountTransactions(context.Context, int64, *api.ListAccountTransactionsRequest) (*api.Page[*models.Transaction], error)
+	GetInstallments(context.Context, int64) ([]*models.Installment, error)
+	ReverseTransaction(context.Context, int64, *api.ReverseTransactionRequest) (*models.Transaction, error)
+	CaptureTransaction(context.Context, int64, *api.CaptureTransactionRequest) (*models.Transaction, error)
+	VoidTransaction(context.Context, int64) (*models.Transaction, error)
+	GetOperationTypes(context.Context, *api.ListOperationTypesRequest) ([]*models.OperationType, error)
+	CreateOperationType(context.Context, *api.OperationTypeRequest) (*models.OperationType, error)
+	UpdateOperationType(context.Context, int64, *api.OperationTypeRequest) (*models.OperationType, error)
+	DeactivateOperationType(context.Context, int64) (*models.OperationType, error)
+	CreateFXRate(context.Context, *api.CreateFXRateRequest) (*models.FXRate, error)
+	GetFXRates(context.Context, *api.ListFXRatesRequest) (*api.Page[*models.FXRate], error)
+	CreateTransfer(context.Context, *api.CreateTransferRequest) (*models.Transfer, error)
+	GetTransfer(context.Context, int64) (*models.Transfer, error)
+	GetJournalEntry(context.Context, int64) (*models.JournalEntry, error)
+	GetTrialBalance(context.Context) ([]*models.LedgerAccountBalance, error)
+	CloseStatements(context.Context, time.Time) ([]*models.Statement, error)
+	GetAccountStatements(context.Context, int64, *api.ListStatementsRequest) (*api.Page[*models.Statement], error)
+	GetStatement(context.Context, int64) (*models.Statement, error)
+	Accrue(context.Context, time.Time) ([]*models.Accrual, error)
+	CreateScheduledTransaction(context.Context, *api.CreateScheduledTransactionRequest) (*models.ScheduledTransaction, error)
+	GetScheduledTransaction(context.Context, int64) (*models.ScheduledTransaction, error)
+	CancelScheduledTransaction(context.Context, int64) (*models.ScheduledTransaction, error)
+	RunScheduledTransactions(context.Context, time.Time, time.Duration) ([]*models.ScheduledExecution, error)
 }
 
 type txnSrv struct {
 	accountRepo     repo.Account
 	transactionRepo repo.Transaction
 	operationRepo   repo.Operation
+	installmentRepo repo.Installment
+	customerRepo    repo.Customer
+	fxRateRepo      repo.FXRate
+	transferRepo    repo.Transfer
+	ledgerRepo      repo.Ledger
+	statementRepo   repo.Statement
+	accrualRepo     repo.Accrual
+	scheduledRepo   repo.ScheduledTransaction
+	idempotencyRepo repo.IdempotencyKey
 }
 
 var _ TransactionService = (*txnSrv)(nil)
 
-func NewTransactionService(
-	accountRepo repo.Account,
-	transactionRepo repo.Transaction,
-	operationRepo repo.Operation,
-) TransactionService {
+// Repos are the repositories the service works with, a test only sets the ones it uses
+type Repos struct {
+	Account              repo.Account
+	Transaction          repo.Transaction
+	Operation            repo.Operation
+	Installment          repo.Installment
+	Customer             repo.Customer
+	FXRate               repo.FXRate
+	Transfer             repo.Transfer
+	Ledger               repo.Ledger
+	Statement            repo.Statement
+	Accrual              repo.Accrual
+	ScheduledTransaction repo.ScheduledTransaction
+	IdempotencyKey       repo.IdempotencyKey
+}
+
+func NewTransactionService(repos Repos) TransactionService {
 	return &txnSrv{
-		accountRepo:     accountRepo,
-		transactionRepo: transactionRepo,
-		operationRepo:   operationRepo,
+		accountRepo:     repos.Account,
+		transactionRepo: repos.Transaction,
+		operationRepo:   repos.Operation,
+		installmentRepo: repos.Installment,
+		customerRepo:    repos.Customer,
+		fxRateRepo:      repos.FXRate,
+		transferRepo:    repos.Transfer,
+		ledgerRepo:      repos.Ledger,
+		statementRepo:   repos.Statement,
+		accrualRepo:     repos.Accrual,
+		scheduledRepo:   repos.ScheduledTransaction,
+		idempotencyRepo: repos.IdempotencyKey,
 	}
 }
 
@@ -41,16 +109,100 @@ func (s *txnSrv) GetAccountByID(ctx context.Context, id int64) (*models.Account,
 	return s.accountRepo.GetByID(ctx, id, true)
 }
 
+// GetBalance reads the current balance of an account, kept up to date as its transactions are posted
+// Fetches the account first so that a missing account results in a 404 instead of a zero balance
+func (s *txnSrv) GetBalance(ctx context.Context, accountID int64) (*api.AccountBalanceResponse, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID, false)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.transactionRepo.GetBalance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &api.AccountBalanceResponse{
+		AccountID:    accountID,
+		Currency:     account.Currency.String(),
+		Balance:      money.New(balance.TotalCredits.Add(balance.TotalDebits)),
+		TotalCredits: money.New(balance.TotalCredits),
+		TotalDebits:  money.New(balance.TotalDebits.Abs()),
+	}, nil
+}
+
+// CreateAccount creates a new account for an existing customer, or for the customer of a document number
+// which is created (without name and birth date) the first time the document number is seen
+// The document number is stored without punctuation, accounts cannot be opened for erased customers
+// The currency defaults to BRL and the credit limit, if any, cannot have more decimals than the currency
+// The billing cycle closes on the 1st and statements are due 10 days later unless given
 func (s *txnSrv) CreateAccount(ctx context.Context, req *api.CreateAccountRequest) (*models.Account, error) {
-	return s.accountRepo.Create(ctx, &models.Account{
-		DocNum: req.DocNum,
+	if req.AvailableCreditLimit != nil && req.AvailableCreditLimit.IsNegative() {
+		return nil, api.BadRequestErr(api.ErrNegativeCreditLimit, nil)
+	}
+	if req.CustomerID != 0 && req.DocNum != "" {
+		return nil, api.BadRequestErr(api.ErrCustomerOrDocument, nil)
+	}
+	currency := models.DefaultCurrency
+	if req.Currency != "" {
+		currency = models.Currency(strings.ToUpper(req.Currency))
+		if err := currency.Validate(); err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidCurrency, err)
+		}
+	}
+	if req.AvailableCreditLimit != nil && !currency.Fits(req.AvailableCreditLimit.Decimal) {
+		return nil, api.BadRequestErr(api.ErrAmountPrecision, nil)
+	}
+
+	var account *models.Account
+	err := s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		var customer *models.Customer
+		var err error
+		if req.CustomerID != 0 {
+			if customer, err = s.customerRepo.GetByID(ctx, req.CustomerID); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return api.BadRequestErr(api.ErrCustomerNotFound, err)
+				}
+				return err
+			}
+			if customer.ErasedAt != nil {
+				return api.UnprocessableEntityErr(api.ErrCustomerErased, nil)
+			}
+		} else if customer, err = s.customerRepo.FindOrCreateByDocument(ctx, &models.Customer{DocNum: api.NormalizeDocument(req.DocNum)}); err != nil {
+			return err
+		}
+
+		account, err = s.accountRepo.Create(ctx, &models.Account{
+			CustomerID:           customer.ID,
+			Currency:             currency,
+			AvailableCreditLimit: req.AvailableCreditLimit,
+			Status:               models.AccountStatusActive,
+			Metadata:             req.Metadata,
+			ClosingDay:           cmp.Or(req.ClosingDay, models.DefaultClosingDay),
+			DueDays:              cmp.Or(req.DueDays, models.DefaultDueDays),
+		})
+		if err != nil {
+			return err
+		}
+		account.Customer = customer
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
 }
 
-// CreateTransaction creates a new transaction
-// Truncates the amount to two decimal places before storing
-// Validates the operation type exists and also finds out negative/positive amount based on credit/debit entryType
-// No need to validate account ID since foreign key constraint will complain on DB insert (same for operation type id actually)
+// CreateTransaction creates a new transaction in the currency of its account
+// A transaction given in another currency (e.g. a purchase abroad) is converted with the FX rate of the pair valid now,
+// the original amount, original currency and applied rate are kept on the transaction for audit
+// The amount cannot have more decimals than the currency it is given in
+// Validates the operation type exists and is active, and also finds out negative/positive amount based on credit/debit entryType
+// The account row is locked for the whole DB transaction so concurrent debits cannot overdraw the available credit limit
+// Debits on blocked accounts and any transaction on closed accounts are rejected
+// A debit is rejected if it exceeds the available credit limit, a credit restores the limit
+// A credit is used to discharge the open debits of the account (oldest first) and only the left over stays on the credit row
+// For operation types allowing installments, the installment schedule is created along with the purchase
+// With capture=false a debit is only authorized: it stays pending, holding the credit limit until it is captured or voided
+// A posted transaction is booked in the double-entry books in the same DB transaction, see journal
 func (s *txnSrv) CreateTransaction(ctx context.Context, req *api.CreateTransactionRequest) (*models.Transaction, error) {
 	// Get operation by id
 	operation, err := s.operationRepo.GetByID(ctx, req.OperationTypeID, false)
@@ -62,23 +214,348 @@ func (s *txnSrv) CreateTransaction(ctx context.Context, req *api.CreateTransacti
 	if operation == nil {
 		return nil, api.BadRequestErr(api.ErrOpTypeNotFound, nil)
 	}
+	if !operation.Active {
+		return nil, api.UnprocessableEntityErr(api.ErrOpTypeInactive, nil)
+	}
+	if operation.Code != "" {
+		return nil, api.UnprocessableEntityErr(api.ErrOpTypeReserved, nil)
+	}
+	if !operation.Installments && (req.InstallmentCount > 0 || req.FirstDueDate != nil) {
+		return nil, api.BadRequestErr(api.ErrInstallmentsNotAllowed, nil)
+	}
+	authorizeOnly := req.Capture != nil && !*req.Capture
+	if authorizeOnly && (operation.EntryType != models.DebitEntry || operation.Installments) {
+		return nil, api.BadRequestErr(api.ErrAuthorizationNotAllowed, nil)
+	}
+	var currency models.Currency
+	if req.Currency != "" {
+		currency = models.Currency(strings.ToUpper(req.Currency))
+		if err := currency.Validate(); err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidCurrency, err)
+		}
+	}
+
+	// the currency of an account never changes, no need to wait for the row lock to check it
+	account, err := s.accountRepo.GetByID(ctx, req.AccountID, false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, api.BadRequestErr(api.ErrAccountNotFound, nil)
+		}
+		return nil, err
+	}
+	if currency == "" {
+		currency = account.Currency
+	}
+	if !currency.Fits(req.Amount.Decimal) {
+		return nil, api.BadRequestErr(api.ErrAmountPrecision, nil)
+	}
+	var fxRate *models.FXRate
+	if currency != account.Currency {
+		if fxRate, err = s.fxRateRepo.GetValidAt(ctx, currency, account.Currency, time.Now().UTC()); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, api.UnprocessableEntityErr(api.ErrFXRateNotFound, nil)
+			}
+			return nil, err
+		}
+	}
+
 	// positive amount for credit and negative for debit
 	switch operation.EntryType {
 	case models.DebitEntry:
-		req.Amount = req.Amount.Abs().Neg()
+		req.Amount = money.New(req.Amount.Abs().Neg())
 	case models.CreditEntry:
-		req.Amount = req.Amount.Abs()
+		req.Amount = money.New(req.Amount.Abs())
+	}
+	original := req.Amount
+	if fxRate != nil {
+		req.Amount = money.New(fxRate.Convert(original.Decimal))
+		if req.Amount.IsZero() {
+			return nil, api.BadRequestErr(api.ErrConvertedAmountZero, nil)
+		}
+		if err := req.Amount.Check(); err != nil {
+			return nil, api.BadRequestErr(api.ErrAmountTooLarge, err)
+		}
+	}
+	slog.Debug("CreateTransaction", "amount", req.Amount, "currency", account.Currency, "original", original, "original_currency", currency, "operation", operation.EntryType)
+
+	var installments []*models.Installment
+	if operation.Installments {
+		if installments, err = splitInstallments(req, account.Currency); err != nil {
+			return nil, err
+		}
 	}
-	slog.Debug("CreateTransaction", "amount", req.Amount, "operation", operation.EntryType)
 
-	return s.transactionRepo.Create(ctx, &models.Transaction{
+	txn := &models.Transaction{
 		AccountID:       req.AccountID,
 		OperationTypeID: req.OperationTypeID,
+		Currency:        account.Currency,
 		Status:          models.TxnStatusCompleted,
 		Amount:          req.Amount,
+		Balance:         req.Amount,
+	}
+	if fxRate != nil {
+		txn.OriginalAmount = &original
+		txn.OriginalCurrency = currency
+		txn.FXRate = &fxRate.Rate
+		txn.FXRateID = &fxRate.ID
+	}
+	if authorizeOnly {
+		txn.Status = models.TxnStatusPending
+	}
+	var created *models.Transaction
+	err = s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		// the entry type gave the sign of the amount, the type row is held so it cannot change until the
+		// transaction is booked, see UpdateOperationType
+		shared, err := s.operationRepo.GetByIDForShare(ctx, req.OperationTypeID)
+		if err != nil {
+			return err
+		}
+		if shared.EntryType != operation.EntryType {
+			return api.ConflictErr(api.ErrEntryTypeChanged, nil)
+		}
+		if _, err := s.applyCreditLimit(ctx, req.AccountID, txn.Amount.Decimal); err != nil {
+			return err
+		}
+
+		if operation.EntryType == models.CreditEntry {
+			remaining, err := s.discharge(ctx, req.AccountID, txn.Amount.Decimal)
+			if err != nil {
+				return err
+			}
+			txn.Balance = money.New(remaining)
+		}
+		if created, err = s.transactionRepo.Create(ctx, txn); err != nil {
+			return err
+		}
+		if !authorizeOnly {
+			if err := s.journal(ctx, created); err != nil {
+				return err
+			}
+		}
+
+		if len(installments) > 0 {
+			for _, installment := range installments {
+				installment.TransactionID = created.ID
+			}
+			if created.Installments, err = s.installmentRepo.CreateMany(ctx, installments); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// applyCreditLimit locks the account row until the DB transaction ends and adds the signed amount to its available
+// credit limit, see moveCreditLimit
+func (s *txnSrv) applyCreditLimit(ctx context.Context, accountID int64, amount decimal.Decimal) (*models.Account, error) {
+	account, err := s.lockAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.moveCreditLimit(ctx, account, amount); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// lockAccount locks the account row until the DB transaction ends
+// Paths locking an account and some of its transactions lock the account first, like CreateTransaction does (the
+// account, then the open debits it discharges), so that concurrent requests on the account queue instead of deadlocking
+func (s *txnSrv) lockAccount(ctx context.Context, accountID int64) (*models.Account, error) {
+	account, err := s.accountRepo.GetByIDForUpdate(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, api.BadRequestErr(api.ErrAccountNotFound, nil)
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// lockWithAccount locks the account of a transaction and then the transaction itself until the DB transaction ends
+// The account of a transaction never changes, so it is read before any row is locked
+func (s *txnSrv) lockWithAccount(ctx context.Context, id int64) (*models.Transaction, *models.Account, error) {
+	txn, err := s.transactionRepo.GetByID(ctx, id, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	account, err := s.lockAccount(ctx, txn.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if txn, err = s.transactionRepo.GetByIDForUpdate(ctx, id); err != nil {
+		return nil, nil, err
+	}
+	return txn, account, nil
+}
+
+// moveCreditLimit adds the signed amount to the available credit limit of a locked account
+// Debits (negative amounts) are rejected if they exceed the limit, credits (positive amounts) first pay back what the
+// account owes over its limit (interest and fees, see chargeFee) and restore the limit with the rest, up to what the column holds
+// Closed accounts reject any movement and blocked accounts reject debits, accounts without a limit or a zero amount only
+// have their status checked
+func (s *txnSrv) moveCreditLimit(ctx context.Context, account *models.Account, amount decimal.Decimal) error {
+	switch {
+	case account.Status == models.AccountStatusClosed:
+		return api.UnprocessableEntityErr(api.ErrAccountClosed, nil)
+	case account.Status == models.AccountStatusBlocked && amount.IsNegative():
+		return api.UnprocessableEntityErr(api.ErrAccountBlocked, nil)
+	}
+	if account.AvailableCreditLimit == nil || amount.IsZero() {
+		return nil
+	}
+	repaid := decimal.Zero
+	if amount.IsPositive() {
+		repaid = decimal.Min(amount, account.OverLimit.Decimal)
+	}
+	limit := account.AvailableCreditLimit.Add(amount.Sub(repaid))
+	if limit.IsNegative() {
+		return api.UnprocessableEntityErr(api.ErrInsufficientLimit, nil)
+	}
+	if err := money.New(limit).Check(); err != nil {
+		return api.BadRequestErr(api.ErrAmountTooLarge, err)
+	}
+	available := money.New(limit)
+	account.AvailableCreditLimit = &available
+	account.OverLimit = money.New(account.OverLimit.Sub(repaid))
+	return s.accountRepo.UpdateCreditLimit(ctx, account)
+}
+
+// discharge pays off the open debits of an account, oldest first, using the given credit amount
+// Returns the part of the credit that is left over after all debits are paid
+func (s *txnSrv) discharge(ctx context.Context, accountID int64, credit decimal.Decimal) (decimal.Decimal, error) {
+	debits, err := s.transactionRepo.GetOpenDebits(ctx, accountID)
+	if err != nil {
+		return credit, err
+	}
+	for _, debit := range debits {
+		if !credit.IsPositive() {
+			break
+		}
+		paid := decimal.Min(credit, debit.Balance.Abs())
+		debit.Balance = money.New(debit.Balance.Add(paid))
+		credit = credit.Sub(paid)
+		slog.Debug("discharge", "transaction", debit.ID, "paid", paid, "remaining", debit.Balance)
+		if err := s.transactionRepo.UpdateBalance(ctx, debit); err != nil {
+			return credit, err
+		}
+	}
+	return credit, nil
+}
+
+// splitInstallments builds the installment schedule of a purchase, one installment per month starting at the first due date
+// Each installment is the amount divided by the count truncated to the decimals of the currency, the rounding left over
+// goes to the first installment so the schedule always sums up to the purchase amount
+func splitInstallments(req *api.CreateTransactionRequest, currency models.Currency) ([]*models.Installment, error) {
+	count := max(req.InstallmentCount, 1)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	firstDueDate := addMonths(today, 1)
+	if req.FirstDueDate != nil {
+		if req.FirstDueDate.Before(today) {
+			return nil, api.BadRequestErr(api.ErrDueDateInPast, nil)
+		}
+		firstDueDate = req.FirstDueDate.Time
+	}
+
+	part := req.Amount.Div(decimal.NewFromInt(int64(count))).Truncate(currency.Exponent())
+	if part.IsZero() {
+		return nil, api.BadRequestErr(api.ErrInstallmentTooSmall, nil)
+	}
+	leftover := req.Amount.Sub(part.Mul(decimal.NewFromInt(int64(count))))
+
+	installments := make([]*models.Installment, count)
+	for i := range installments {
+		installments[i] = &models.Installment{
+			Number:  i + 1,
+			Amount:  money.New(part),
+			DueDate: addMonths(firstDueDate, i),
+		}
+	}
+	installments[0].Amount = money.New(part.Add(leftover))
+	return installments, nil
+}
+
+// addMonths adds n months to t, clamping the day to the last day of the resulting month (Jan 31 + 1 month = Feb 28)
+func addMonths(t time.Time, n int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	return firstOfMonth.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// GetInstallments fetches the installment schedule of a transaction
+// Fetches the transaction first so that a missing transaction results in a 404 instead of an empty schedule
+func (s *txnSrv) GetInstallments(ctx context.Context, transactionID int64) ([]*models.Installment, error) {
+	if _, err := s.transactionRepo.GetByID(ctx, transactionID, false); err != nil {
+		return nil, err
+	}
+	return s.installmentRepo.GetByTransactionID(ctx, transactionID)
+}
+
+// GetTransactions fetches one page of transactions matching the optional filters of the request
+// Amount filters apply to the absolute amount since debits are stored as negative amounts
+func (s *txnSrv) GetTransactions(ctx context.Context, req *api.ListTransactionsRequest) (*api.Page[*models.Transaction], error) {
+	cursor, err := repo.DecodeCursor(req.Cursor, "")
+	if err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidCursor, err)
+	}
+	q := repo.NewListQuery().Page(cursor, req.Limit)
+	if req.AccountID != 0 {
+		q.Eq("account_id", req.AccountID)
+	}
+	if req.OperationTypeID != 0 {
+		q.Eq("operation_type_id", req.OperationTypeID)
+	}
+	if req.Status != "" {
+		if err := models.TxnStatus(req.Status).Validate(); err != nil {
+			return nil, api.BadRequestErr(api.ErrInvalidStatus, err)
+		}
+		q.Eq("status", req.Status)
+	}
+	if req.EventDateFrom != nil {
+		q.Gte("event_date", *req.EventDateFrom)
+	}
+	if req.EventDateTo != nil {
+		q.Lte("event_date", *req.EventDateTo)
+	}
+	if req.MinAmount != nil {
+		q.Where("ABS(?TableAlias.amount) >= ?", req.MinAmount.Abs())
+	}
+	if req.MaxAmount != nil {
+		q.Where("ABS(?TableAlias.amount) <= ?", req.MaxAmount.Abs())
+	}
+
+	transactions, next, err := s.transactionRepo.List(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Page[*models.Transaction]{Items: transactions, NextCursor: next.Encode()}, nil
 }
 
-func (s *txnSrv) GetTransactions(ctx context.Context) ([]*models.Transaction, error) {
-	return s.transactionRepo.GetAllTransactions(ctx)
+// GetAccountTransactions fetches one page of the transactions of an account, sorted as requested (by id by default)
+// Fetches the account first so that a missing account results in a 404 instead of an empty page
+func (s *txnSrv) GetAccountTransactions(ctx context.Context, accountID int64, req *api.ListAccountTransactionsRequest) (*api.Page[*models.Transaction], error) {
+	if _, err := s.accountRepo.GetByID(ctx, accountID, false); err != nil {
+		return nil, err
+	}
+	cursor, err := repo.DecodeCursor(req.Cursor, req.Sort)
+	if err != nil {
+		return nil, api.BadRequestErr(api.ErrInvalidCursor, err)
+	}
+	q := repo.NewListQuery().Page(cursor, req.Limit)
+	if req.Sort != "" {
+		q.OrderBy(strings.TrimPrefix(req.Sort, "-"), strings.HasPrefix(req.Sort, "-"))
+	}
+	if req.Embed == "operation_type" {
+		q.Relation("OperationType")
+	}
+
+	transactions, next, err := s.transactionRepo.GetByAccountID(ctx, accountID, q)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Page[*models.Transaction]{Items: transactions, NextCursor: next.Encode()}, nil
 }
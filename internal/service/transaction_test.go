@@ -2,40 +2,185 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/internal/storage/repo"
 	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
 	"github.com/akhiltak/pismo-api/pkg/api"
 	"github.com/labstack/echo/v4"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/uptrace/bun"
 	"go.uber.org/mock/gomock"
 )
 
+// runInTx executes the callback given to a mocked RunInTx as if it was running in a transaction
+func runInTx(ctx context.Context, _ *sql.TxOptions, f func(context.Context, bun.Tx) error) error {
+	return f(ctx, bun.Tx{})
+}
+
+// amount builds an amount for fixtures, exact for the values used in tests
+func amount(f float64) models.Money {
+	return models.Money{Decimal: decimal.NewFromFloat(f)}
+}
+
+// creditLimit builds the available credit limit of an account fixture
+func creditLimit(f float64) *models.Money {
+	m := amount(f)
+	return &m
+}
+
 func TestCreateAccount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
-	service := NewTransactionService(mockAccountRepo, nil, nil)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockCustomerRepo := mockRepo.NewMockCustomer(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Customer: mockCustomerRepo})
 
 	t.Run("successful creation", func(t *testing.T) {
-		req := &api.CreateAccountRequest{DocNum: "12345678"}
-		expectedAccount := &models.Account{ID: 1, DocNum: "12345678"}
+		req := &api.CreateAccountRequest{DocNum: "12345678143"}
+		customer := &models.Customer{ID: 7, DocNum: "12345678143"}
 
-		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(expectedAccount, nil)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().FindOrCreateByDocument(gomock.Any(), gomock.Any()).Return(customer, nil)
+		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Account{ID: 1, CustomerID: 7}, nil)
 
 		account, err := service.CreateAccount(context.Background(), req)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedAccount, account)
+		assert.Equal(t, int64(1), account.ID)
+		assert.Equal(t, customer, account.Customer)
+	})
+
+	t.Run("document number is normalized", func(t *testing.T) {
+		req := &api.CreateAccountRequest{DocNum: "123.456.781-43"}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().FindOrCreateByDocument(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, customer *models.Customer) (*models.Customer, error) {
+				assert.Equal(t, "12345678143", customer.DocNum)
+				customer.ID = 7
+				return customer, nil
+			})
+		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, account *models.Account) (*models.Account, error) {
+				assert.Equal(t, int64(7), account.CustomerID)
+				return account, nil
+			})
+
+		_, err := service.CreateAccount(context.Background(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("existing customer", func(t *testing.T) {
+		req := &api.CreateAccountRequest{CustomerID: 7}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&models.Customer{ID: 7}, nil)
+		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, account *models.Account) (*models.Account, error) {
+				assert.Equal(t, int64(7), account.CustomerID)
+				return account, nil
+			})
+
+		account, err := service.CreateAccount(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), account.Customer.ID)
+	})
+
+	t.Run("customer not found", func(t *testing.T) {
+		req := &api.CreateAccountRequest{CustomerID: 8}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().GetByID(gomock.Any(), int64(8)).Return(nil, sql.ErrNoRows)
+
+		account, err := service.CreateAccount(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrCustomerNotFound, he.Message)
+		assert.Nil(t, account)
+	})
+
+	t.Run("erased customer", func(t *testing.T) {
+		req := &api.CreateAccountRequest{CustomerID: 9}
+		erasedAt := time.Now()
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().GetByID(gomock.Any(), int64(9)).Return(&models.Customer{ID: 9, ErasedAt: &erasedAt}, nil)
+
+		account, err := service.CreateAccount(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, account)
+	})
+
+	t.Run("both customer and document number", func(t *testing.T) {
+		req := &api.CreateAccountRequest{CustomerID: 7, DocNum: "12345678143"}
+
+		account, err := service.CreateAccount(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, account)
+	})
+
+	t.Run("currency", func(t *testing.T) {
+		req := &api.CreateAccountRequest{CustomerID: 7, Currency: "jpy", AvailableCreditLimit: creditLimit(50000)}
+
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&models.Customer{ID: 7}, nil)
+		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, account *models.Account) (*models.Account, error) {
+				return account, nil
+			})
+
+		account, err := service.CreateAccount(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, models.Currency("JPY"), account.Currency)
+	})
+
+	t.Run("invalid currency or credit limit precision", func(t *testing.T) {
+		for _, req := range []*api.CreateAccountRequest{
+			{CustomerID: 7, Currency: "XYZ"},
+			{CustomerID: 7, Currency: "JPY", AvailableCreditLimit: creditLimit(0.5)},
+			{CustomerID: 7, AvailableCreditLimit: creditLimit(10.001)},
+		} {
+			account, err := service.CreateAccount(context.Background(), req)
+			assert.Error(t, err)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Nil(t, account)
+		}
+	})
+
+	t.Run("negative credit limit", func(t *testing.T) {
+		req := &api.CreateAccountRequest{DocNum: "12345678143", AvailableCreditLimit: creditLimit(-1)}
+
+		account, err := service.CreateAccount(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, account)
 	})
 
 	t.Run("repo error", func(t *testing.T) {
-		req := &api.CreateAccountRequest{DocNum: "12345678"}
+		req := &api.CreateAccountRequest{DocNum: "12345678143"}
 
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockCustomerRepo.EXPECT().FindOrCreateByDocument(gomock.Any(), gomock.Any()).Return(&models.Customer{ID: 7}, nil)
 		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
 		account, err := service.CreateAccount(context.Background(), req)
@@ -49,10 +194,10 @@ func TestGetAccountByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
-	service := NewTransactionService(mockAccountRepo, nil, nil)
+	service := NewTransactionService(Repos{Account: mockAccountRepo})
 
 	t.Run("successful retrieval", func(t *testing.T) {
-		expectedAccount := &models.Account{ID: 1, DocNum: "12345678"}
+		expectedAccount := &models.Account{ID: 1, CustomerID: 7, Customer: &models.Customer{ID: 7, DocNum: "12345678143"}}
 
 		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), true).Return(expectedAccount, nil)
 
@@ -70,36 +215,112 @@ func TestGetAccountByID(t *testing.T) {
 	})
 }
 
+func TestGetBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1, Currency: "USD"}, nil)
+		mockTransactionRepo.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(&models.Balance{
+			AccountID:    1,
+			TotalCredits: decimal.NewFromFloat(150),
+			TotalDebits:  decimal.NewFromFloat(-100.50),
+		}, nil)
+
+		balance, err := service.GetBalance(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), balance.AccountID)
+		assert.Equal(t, "USD", balance.Currency)
+		assert.True(t, decimal.NewFromFloat(49.50).Equal(balance.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(150).Equal(balance.TotalCredits.Decimal))
+		assert.True(t, decimal.NewFromFloat(100.50).Equal(balance.TotalDebits.Decimal))
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(nil, sql.ErrNoRows)
+
+		balance, err := service.GetBalance(context.Background(), 2)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, balance)
+	})
+
+	t.Run("cursor of another sort", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1}, nil)
+
+		cursor := &repo.Cursor{Sort: "-amount", Value: "-50.25", ID: 1}
+		page, err := service.GetAccountTransactions(context.Background(), 1, &api.ListAccountTransactionsRequest{Sort: "event_date", Cursor: cursor.Encode()})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrInvalidCursor, he.Message)
+		assert.Nil(t, page)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(nil, assert.AnError)
+
+		balance, err := service.GetBalance(context.Background(), 1)
+		assert.Error(t, err)
+		assert.Nil(t, balance)
+	})
+}
+
 func TestCreateTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
 	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
 	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
-	service := NewTransactionService(nil, mockTransactionRepo, mockOperationRepo)
+	mockInstallmentRepo := mockRepo.NewMockInstallment(ctrl)
+	mockFXRateRepo := mockRepo.NewMockFXRate(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Operation: mockOperationRepo, Installment: mockInstallmentRepo, FXRate: mockFXRateRepo, Ledger: acceptJournal(ctrl)})
+
+	// findAccount expects the account to be read for its currency
+	findAccount := func(currency models.Currency) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1, Currency: currency}, nil)
+	}
+
+	// lockAccount expects the account row to be locked and its credit limit to be updated
+	lockAccount := func(limit float64) *models.Account {
+		account := &models.Account{ID: 1, AvailableCreditLimit: creditLimit(limit)}
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil)
+		return account
+	}
 
 	// operation types
-	op1 := &models.OperationType{ID: 1, Description: "Normal Purchase", EntryType: models.DebitEntry}
-	// op2 := &models.OperationType{ID: 2, Description: "Purchase with installments", EntryType: models.DebitEntry}
-	// op3 := &models.OperationType{ID: 3, Description: "Withdrawal", EntryType: models.DebitEntry}
-	op4 := &models.OperationType{ID: 4, Description: "Credit Voucher", EntryType: models.CreditEntry}
+	op1 := &models.OperationType{ID: 1, Description: "Normal Purchase", EntryType: models.DebitEntry, Active: true}
+	op2 := &models.OperationType{ID: 2, Description: "Purchase with installments", EntryType: models.DebitEntry, Installments: true, Active: true}
+	// op3 := &models.OperationType{ID: 3, Description: "Withdrawal", EntryType: models.DebitEntry, Active: true}
+	op4 := &models.OperationType{ID: 4, Description: "Credit Voucher", EntryType: models.CreditEntry, Active: true}
 
 	t.Run("successful creation - debit", func(t *testing.T) {
 		req := &api.CreateTransactionRequest{
 			AccountID:       1,
 			OperationTypeID: 1,
-			Amount:          decimal.NewFromFloat(100.50),
+			Amount:          amount(100.50),
 		}
 
 		expectedTransaction := &models.Transaction{
 			ID:              1,
 			AccountID:       1,
 			OperationTypeID: 1,
-			Amount:          decimal.NewFromFloat(-100.50),
+			Amount:          amount(-100.50),
 			Status:          models.TxnStatusCompleted,
 		}
 
 		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
 		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(expectedTransaction, nil)
 
 		transaction, err := service.CreateTransaction(context.Background(), req)
@@ -107,21 +328,45 @@ func TestCreateTransaction(t *testing.T) {
 		assert.Equal(t, expectedTransaction, transaction)
 	})
 
+	t.Run("entry type changed meanwhile", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{AccountID: 1, OperationTypeID: 1, Amount: amount(100.50)}
+		updated := *op1
+		updated.EntryType = models.CreditEntry
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(&updated, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, api.ErrEntryTypeChanged, he.Message)
+		assert.Nil(t, transaction)
+	})
+
 	t.Run("successful creation - credit", func(t *testing.T) {
 		req := &api.CreateTransactionRequest{
 			AccountID:       1,
 			OperationTypeID: 2,
-			Amount:          decimal.NewFromFloat(100.50),
+			Amount:          amount(100.50),
 		}
 		expectedTransaction := &models.Transaction{
 			ID:              1,
 			AccountID:       1,
 			OperationTypeID: 2,
-			Amount:          decimal.NewFromFloat(100.50),
+			Amount:          amount(100.50),
 			Status:          models.TxnStatusCompleted,
 		}
 
 		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(2)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return(nil, nil)
 		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(expectedTransaction, nil)
 
 		transaction, err := service.CreateTransaction(context.Background(), req)
@@ -129,11 +374,615 @@ func TestCreateTransaction(t *testing.T) {
 		assert.Equal(t, expectedTransaction, transaction)
 	})
 
+	t.Run("credit discharges open debits oldest first", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(60),
+		}
+		debit1 := &models.Transaction{ID: 1, AccountID: 1, Amount: amount(-50), Balance: amount(-50)}
+		debit2 := &models.Transaction{ID: 2, AccountID: 1, Amount: amount(-23.5), Balance: amount(-23.5)}
+		debit3 := &models.Transaction{ID: 3, AccountID: 1, Amount: amount(-18.7), Balance: amount(-18.7)}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return([]*models.Transaction{debit1, debit2, debit3}, nil)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), debit1).Return(nil)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), debit2).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.True(t, decimal.Zero.Equal(debit1.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(-13.5).Equal(debit2.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(-18.7).Equal(debit3.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(60).Equal(transaction.Amount.Decimal))
+		assert.True(t, decimal.Zero.Equal(transaction.Balance.Decimal))
+	})
+
+	t.Run("credit left over stays on the credit", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(100),
+		}
+		debit := &models.Transaction{ID: 1, AccountID: 1, Amount: amount(-50), Balance: amount(-40)}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return([]*models.Transaction{debit}, nil)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), debit).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.True(t, decimal.Zero.Equal(debit.Balance.Decimal))
+		assert.True(t, decimal.NewFromFloat(60).Equal(transaction.Balance.Decimal))
+	})
+
+	t.Run("discharge error rolls back", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(100),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return(nil, assert.AnError)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("debit consumes the available credit limit", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(100.50),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		account := lockAccount(100.50)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		_, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.True(t, decimal.Zero.Equal(account.AvailableCreditLimit.Decimal))
+	})
+
+	t.Run("debit on an account without a limit", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(1000000),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		// no UpdateCreditLimit expected
+		account := &models.Account{ID: 1, Currency: models.DefaultCurrency, Status: models.AccountStatusActive}
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "-1000000", transaction.Amount.String())
+		assert.Nil(t, account.AvailableCreditLimit)
+	})
+
+	t.Run("credit restores the available credit limit", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(25),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		account := lockAccount(75)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return(nil, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		_, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(100).Equal(account.AvailableCreditLimit.Decimal))
+	})
+
+	t.Run("credit on an account over its limit", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(5),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		// interest charged over the limit
+		account := lockAccount(0)
+		account.OverLimit = amount(3.5)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return(nil, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		_, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		// the credit pays back what is owed over the limit before restoring it
+		assert.Equal(t, "0", account.OverLimit.String())
+		assert.Equal(t, "1.5", account.AvailableCreditLimit.String())
+	})
+
+	t.Run("debit exceeding the available credit limit", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(100.50),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{
+			ID:                   1,
+			AvailableCreditLimit: creditLimit(100.49),
+		}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("credit pushing the available credit limit past the column", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(1),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{
+			ID:                   1,
+			AvailableCreditLimit: &models.Money{Decimal: decimal.RequireFromString("999999999999999.9999")},
+		}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrAmountTooLarge, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("debit on a blocked account", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(10),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{
+			ID: 1, Status: models.AccountStatusBlocked, AvailableCreditLimit: creditLimit(1000),
+		}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrAccountBlocked, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("credit on a blocked account", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(10),
+		}
+		account := &models.Account{ID: 1, Status: models.AccountStatusBlocked, AvailableCreditLimit: creditLimit(0)}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(account, nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), account).Return(nil)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(1)).Return(nil, nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.True(t, decimal.NewFromFloat(10).Equal(transaction.Amount.Decimal))
+	})
+
+	t.Run("credit on a closed account", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(10),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(4)).Return(op4, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1, Status: models.AccountStatusClosed}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrAccountClosed, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(100.50),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(nil, sql.ErrNoRows)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("takes the currency of the account", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(12.345),
+			Currency:        "bhd",
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount("BHD")
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, models.Currency("BHD"), transaction.Currency)
+		assert.True(t, decimal.NewFromFloat(-12.345).Equal(transaction.Amount.Decimal))
+	})
+
+	t.Run("purchase abroad is converted to the account currency", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(10.99),
+			Currency:        "usd",
+		}
+		rate := &models.FXRate{ID: 3, BaseCurrency: "USD", QuoteCurrency: models.DefaultCurrency, Rate: decimal.RequireFromString("5.4321")}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockFXRateRepo.EXPECT().GetValidAt(gomock.Any(), models.Currency("USD"), models.DefaultCurrency, gomock.Any()).Return(rate, nil)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		account := lockAccount(100)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		// 10.99 * 5.4321 = 59.698779, rounded to the cents of BRL
+		assert.Equal(t, models.DefaultCurrency, transaction.Currency)
+		assert.Equal(t, "-59.7", transaction.Amount.String())
+		assert.Equal(t, "-59.7", transaction.Balance.String())
+		assert.Equal(t, "-10.99", transaction.OriginalAmount.String())
+		assert.Equal(t, models.Currency("USD"), transaction.OriginalCurrency)
+		assert.Equal(t, "5.4321", transaction.FXRate.String())
+		assert.Equal(t, int64(3), *transaction.FXRateID)
+		assert.Equal(t, "40.3", account.AvailableCreditLimit.String())
+	})
+
+	t.Run("no FX rate for the currency pair", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(10),
+			Currency:        "USD",
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockFXRateRepo.EXPECT().GetValidAt(gomock.Any(), models.Currency("USD"), models.DefaultCurrency, gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrFXRateNotFound, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("converted amount rounds to zero", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(1),
+			Currency:        "JPY",
+		}
+		rate := &models.FXRate{ID: 4, BaseCurrency: "JPY", QuoteCurrency: "USD", Rate: decimal.RequireFromString("0.0042")}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		findAccount("USD")
+		mockFXRateRepo.EXPECT().GetValidAt(gomock.Any(), models.Currency("JPY"), models.Currency("USD"), gomock.Any()).Return(rate, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrConvertedAmountZero, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(10),
+			Currency:        "XYZ",
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrInvalidCurrency, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("more decimals than the currency", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(100.5),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		findAccount("JPY")
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrAmountPrecision, he.Message)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("installments without cents", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:        1,
+			OperationTypeID:  2,
+			Amount:           amount(1000),
+			InstallmentCount: 3,
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(op2, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(2)).Return(op2, nil)
+		findAccount("CLP")
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(5000)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+		mockInstallmentRepo.EXPECT().CreateMany(gomock.Any(), gomock.Len(3)).DoAndReturn(
+			func(_ context.Context, installments []*models.Installment) ([]*models.Installment, error) {
+				return installments, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		if assert.Len(t, transaction.Installments, 3) {
+			assert.True(t, decimal.NewFromInt(-334).Equal(transaction.Installments[0].Amount.Decimal))
+			assert.True(t, decimal.NewFromInt(-333).Equal(transaction.Installments[1].Amount.Decimal))
+			assert.True(t, decimal.NewFromInt(-333).Equal(transaction.Installments[2].Amount.Decimal))
+		}
+	})
+
+	t.Run("authorization without capture", func(t *testing.T) {
+		capture := false
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          amount(40),
+			Capture:         &capture,
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(1)).Return(op1, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		account := lockAccount(100)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, models.TxnStatusPending, transaction.Status)
+		assert.True(t, decimal.NewFromFloat(60).Equal(account.AvailableCreditLimit.Decimal))
+	})
+
+	t.Run("credit cannot be authorized without capture", func(t *testing.T) {
+		capture := false
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 4,
+			Amount:          amount(40),
+			Capture:         &capture,
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(4), false).Return(op4, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("purchase with installments", func(t *testing.T) {
+		firstDueDate := time.Date(2100, time.January, 15, 0, 0, 0, 0, time.UTC)
+		req := &api.CreateTransactionRequest{
+			AccountID:        1,
+			OperationTypeID:  2,
+			Amount:           amount(100),
+			InstallmentCount: 3,
+			FirstDueDate:     &api.Date{Time: firstDueDate},
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(op2, nil)
+		mockOperationRepo.EXPECT().GetByIDForShare(gomock.Any(), int64(2)).Return(op2, nil)
+		findAccount(models.DefaultCurrency)
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		lockAccount(1000)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				txn.ID = 10
+				return txn, nil
+			})
+		mockInstallmentRepo.EXPECT().CreateMany(gomock.Any(), gomock.Len(3)).DoAndReturn(
+			func(_ context.Context, installments []*models.Installment) ([]*models.Installment, error) {
+				return installments, nil
+			})
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.NoError(t, err)
+		if assert.Len(t, transaction.Installments, 3) {
+			total := decimal.Zero
+			for i, installment := range transaction.Installments {
+				assert.Equal(t, int64(10), installment.TransactionID)
+				assert.Equal(t, i+1, installment.Number)
+				assert.Equal(t, firstDueDate.AddDate(0, i, 0), installment.DueDate)
+				total = total.Add(installment.Amount.Decimal)
+			}
+			assert.True(t, decimal.NewFromFloat(-33.34).Equal(transaction.Installments[0].Amount.Decimal))
+			assert.True(t, decimal.NewFromFloat(-33.33).Equal(transaction.Installments[1].Amount.Decimal))
+			assert.True(t, decimal.NewFromFloat(-33.33).Equal(transaction.Installments[2].Amount.Decimal))
+			assert.True(t, transaction.Amount.Equal(total))
+		}
+	})
+
+	t.Run("installments not allowed for operation type", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:        1,
+			OperationTypeID:  1,
+			Amount:           amount(100),
+			InstallmentCount: 3,
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(op1, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("amount too small for installments", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:        1,
+			OperationTypeID:  2,
+			Amount:           amount(0.05),
+			InstallmentCount: 6,
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(op2, nil)
+		findAccount(models.DefaultCurrency)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, transaction)
+	})
+
 	t.Run("invalid operation type", func(t *testing.T) {
 		req := &api.CreateTransactionRequest{
 			AccountID:       1,
 			OperationTypeID: 3,
-			Amount:          decimal.NewFromFloat(100.50),
+			Amount:          amount(100.50),
 		}
 
 		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(3), false).Return(nil, fmt.Errorf("wrong, something is!!!"))
@@ -147,7 +996,7 @@ func TestCreateTransaction(t *testing.T) {
 		req := &api.CreateTransactionRequest{
 			AccountID:       1,
 			OperationTypeID: 3,
-			Amount:          decimal.NewFromFloat(100.50),
+			Amount:          amount(100.50),
 		}
 
 		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(3), false).Return(nil, nil)
@@ -159,6 +1008,79 @@ func TestCreateTransaction(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, he.Code)
 		assert.Nil(t, transaction)
 	})
+
+	t.Run("inactive operation type", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 3,
+			Amount:          amount(100.50),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(3), false).Return(&models.OperationType{ID: 3, EntryType: models.DebitEntry}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Nil(t, transaction)
+	})
+
+	t.Run("operation type reserved for transfers", func(t *testing.T) {
+		req := &api.CreateTransactionRequest{
+			AccountID:       1,
+			OperationTypeID: 5,
+			Amount:          amount(100.50),
+		}
+
+		mockOperationRepo.EXPECT().GetByID(gomock.Any(), int64(5), false).Return(&models.OperationType{ID: 5, EntryType: models.DebitEntry, Active: true, Code: models.OpCodeTransferOut}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrOpTypeReserved, he.Message)
+		assert.Nil(t, transaction)
+	})
+}
+
+func TestAddMonths(t *testing.T) {
+	jan31 := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC), addMonths(jan31, 1))
+	assert.Equal(t, time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), addMonths(jan31, 2))
+	assert.Equal(t, time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC), addMonths(jan31, 12))
+}
+
+func TestGetInstallments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockInstallmentRepo := mockRepo.NewMockInstallment(ctrl)
+	service := NewTransactionService(Repos{Transaction: mockTransactionRepo, Installment: mockInstallmentRepo})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expectedInstallments := []*models.Installment{
+			{ID: 1, TransactionID: 1, Number: 1, Amount: amount(-50)},
+			{ID: 2, TransactionID: 1, Number: 2, Amount: amount(-50)},
+		}
+
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Transaction{ID: 1}, nil)
+		mockInstallmentRepo.EXPECT().GetByTransactionID(gomock.Any(), int64(1)).Return(expectedInstallments, nil)
+
+		installments, err := service.GetInstallments(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedInstallments, installments)
+	})
+
+	t.Run("transaction not found", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(nil, sql.ErrNoRows)
+
+		installments, err := service.GetInstallments(context.Background(), 2)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, installments)
+	})
 }
 
 func TestGetTransactions(t *testing.T) {
@@ -166,26 +1088,118 @@ func TestGetTransactions(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
-	service := NewTransactionService(nil, mockTransactionRepo, nil)
+	service := NewTransactionService(Repos{Transaction: mockTransactionRepo})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expectedTransactions := []*models.Transaction{
+			{ID: 1, AccountID: 1, Amount: amount(100.50)},
+			{ID: 2, AccountID: 2, Amount: amount(-50.25)},
+		}
+
+		mockTransactionRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(expectedTransactions, nil, nil)
+
+		page, err := service.GetTransactions(context.Background(), &api.ListTransactionsRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTransactions, page.Items)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		expectedTransactions := []*models.Transaction{{ID: 3, AccountID: 1, Amount: amount(-10)}}
+		cursor := &repo.Cursor{ID: 3}
+
+		mockTransactionRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(expectedTransactions, &repo.Cursor{ID: 4}, nil)
+
+		page, err := service.GetTransactions(context.Background(), &api.ListTransactionsRequest{
+			AccountID: 1,
+			Status:    "completed",
+			Cursor:    cursor.Encode(),
+			Limit:     1,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTransactions, page.Items)
+		next, err := repo.DecodeCursor(page.NextCursor, "")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), next.ID)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		page, err := service.GetTransactions(context.Background(), &api.ListTransactionsRequest{Cursor: "not a cursor"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, page)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		page, err := service.GetTransactions(context.Background(), &api.ListTransactionsRequest{Status: "unknown"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, page)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		mockTransactionRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, assert.AnError)
+
+		page, err := service.GetTransactions(context.Background(), &api.ListTransactionsRequest{})
+		assert.Error(t, err)
+		assert.Nil(t, page)
+	})
+}
+
+func TestGetAccountTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo})
 
 	t.Run("successful retrieval", func(t *testing.T) {
 		expectedTransactions := []*models.Transaction{
-			{ID: 1, AccountID: 1, Amount: decimal.NewFromFloat(100.50)},
-			{ID: 2, AccountID: 2, Amount: decimal.NewFromFloat(-50.25)},
+			{ID: 2, AccountID: 1, Amount: amount(100.50), OperationType: &models.OperationType{ID: 4}},
+			{ID: 1, AccountID: 1, Amount: amount(-50.25), OperationType: &models.OperationType{ID: 1}},
 		}
 
-		mockTransactionRepo.EXPECT().GetAllTransactions(gomock.Any()).Return(expectedTransactions, nil)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByAccountID(gomock.Any(), int64(1), gomock.Any()).Return(expectedTransactions, &repo.Cursor{Sort: "-amount", Value: "-50.25", ID: 1}, nil)
 
-		transactions, err := service.GetTransactions(context.Background())
+		page, err := service.GetAccountTransactions(context.Background(), 1, &api.ListAccountTransactionsRequest{Sort: "-amount", Embed: "operation_type", Limit: 2})
 		assert.NoError(t, err)
-		assert.Equal(t, expectedTransactions, transactions)
+		assert.Equal(t, expectedTransactions, page.Items)
+		next, err := repo.DecodeCursor(page.NextCursor, "-amount")
+		assert.NoError(t, err)
+		assert.Equal(t, "-50.25", next.Value)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(2), false).Return(nil, sql.ErrNoRows)
+
+		page, err := service.GetAccountTransactions(context.Background(), 2, &api.ListAccountTransactionsRequest{})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Nil(t, page)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1}, nil)
+
+		page, err := service.GetAccountTransactions(context.Background(), 1, &api.ListAccountTransactionsRequest{Cursor: "not a cursor"})
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Nil(t, page)
 	})
 
 	t.Run("repo error", func(t *testing.T) {
-		mockTransactionRepo.EXPECT().GetAllTransactions(gomock.Any()).Return(nil, assert.AnError)
+		mockAccountRepo.EXPECT().GetByID(gomock.Any(), int64(1), false).Return(&models.Account{ID: 1}, nil)
+		mockTransactionRepo.EXPECT().GetByAccountID(gomock.Any(), int64(1), gomock.Any()).Return(nil, nil, assert.AnError)
 
-		transactions, err := service.GetTransactions(context.Background())
+		page, err := service.GetAccountTransactions(context.Background(), 1, &api.ListAccountTransactionsRequest{})
 		assert.Error(t, err)
-		assert.Nil(t, transactions)
+		assert.Nil(t, page)
 	})
 }
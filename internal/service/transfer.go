@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+// CreateTransfer moves an amount between two accounts of the same currency in a single DB transaction: a debit on the
+// source and a credit on the destination, both linked to the transfer, so a transfer is either fully booked or not at all
+// Both account rows are locked in ascending ID order, so concurrent transfers between the same accounts (in either
+// direction) wait for each other instead of deadlocking
+// The debit follows the rules of any debit (account status, available credit limit) and the credit the rules of any
+// credit, it discharges the open debits of the destination
+func (s *txnSrv) CreateTransfer(ctx context.Context, req *api.CreateTransferRequest) (*models.Transfer, error) {
+	if req.SourceAccountID == req.DestinationAccountID {
+		return nil, api.BadRequestErr(api.ErrTransferSameAccount, nil)
+	}
+	if !req.Amount.IsPositive() {
+		return nil, api.BadRequestErr(api.ErrTransferAmount, nil)
+	}
+	transferOut, err := s.operationRepo.GetByCode(ctx, models.OpCodeTransferOut)
+	if err != nil {
+		return nil, err
+	}
+	transferIn, err := s.operationRepo.GetByCode(ctx, models.OpCodeTransferIn)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer *models.Transfer
+	err = s.transactionRepo.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		accounts := map[int64]*models.Account{}
+		for _, id := range []int64{min(req.SourceAccountID, req.DestinationAccountID), max(req.SourceAccountID, req.DestinationAccountID)} {
+			account, err := s.accountRepo.GetByIDForUpdate(ctx, id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return api.BadRequestErr(api.ErrAccountNotFound, nil)
+				}
+				return err
+			}
+			accounts[id] = account
+		}
+		source, destination := accounts[req.SourceAccountID], accounts[req.DestinationAccountID]
+		if source.Currency != destination.Currency {
+			return api.UnprocessableEntityErr(api.ErrTransferCurrency, nil)
+		}
+		if !source.Currency.Fits(req.Amount.Decimal) {
+			return api.BadRequestErr(api.ErrAmountPrecision, nil)
+		}
+		slog.Debug("CreateTransfer", "source", source.ID, "destination", destination.ID, "amount", req.Amount, "currency", source.Currency)
+
+		var err error
+		transfer, err = s.transferRepo.Create(ctx, &models.Transfer{
+			SourceAccountID:      source.ID,
+			DestinationAccountID: destination.ID,
+			Amount:               req.Amount,
+			Currency:             source.Currency,
+			Description:          req.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		debit := &models.Transaction{
+			AccountID:       source.ID,
+			OperationTypeID: transferOut.ID,
+			Currency:        source.Currency,
+			Status:          models.TxnStatusCompleted,
+			Amount:          money.New(req.Amount.Neg()),
+			Balance:         money.New(req.Amount.Neg()),
+			TransferID:      &transfer.ID,
+		}
+		if _, err := s.applyCreditLimit(ctx, source.ID, debit.Amount.Decimal); err != nil {
+			return err
+		}
+		if debit, err = s.transactionRepo.Create(ctx, debit); err != nil {
+			return err
+		}
+		if err := s.journal(ctx, debit); err != nil {
+			return err
+		}
+
+		credit := &models.Transaction{
+			AccountID:       destination.ID,
+			OperationTypeID: transferIn.ID,
+			Currency:        destination.Currency,
+			Status:          models.TxnStatusCompleted,
+			Amount:          req.Amount,
+			TransferID:      &transfer.ID,
+		}
+		if _, err := s.applyCreditLimit(ctx, destination.ID, credit.Amount.Decimal); err != nil {
+			return err
+		}
+		remaining, err := s.discharge(ctx, destination.ID, credit.Amount.Decimal)
+		if err != nil {
+			return err
+		}
+		credit.Balance = money.New(remaining)
+		if credit, err = s.transactionRepo.Create(ctx, credit); err != nil {
+			return err
+		}
+		if err := s.journal(ctx, credit); err != nil {
+			return err
+		}
+
+		transfer.Transactions = []*models.Transaction{debit, credit}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetTransfer fetches a transfer along with its debit and credit
+func (s *txnSrv) GetTransfer(ctx context.Context, id int64) (*models.Transfer, error) {
+	return s.transferRepo.GetByID(ctx, id)
+}
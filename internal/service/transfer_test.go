@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	mockRepo "github.com/akhiltak/pismo-api/internal/storage/repo/mock_repo"
+	"github.com/akhiltak/pismo-api/pkg/api"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mockRepo.NewMockAccount(ctrl)
+	mockTransactionRepo := mockRepo.NewMockTransaction(ctrl)
+	mockOperationRepo := mockRepo.NewMockOperation(ctrl)
+	mockTransferRepo := mockRepo.NewMockTransfer(ctrl)
+	service := NewTransactionService(Repos{Account: mockAccountRepo, Transaction: mockTransactionRepo, Operation: mockOperationRepo, Transfer: mockTransferRepo, Ledger: acceptJournal(ctrl)})
+
+	transferOut := &models.OperationType{ID: 5, EntryType: models.DebitEntry, Active: true, Code: models.OpCodeTransferOut}
+	transferIn := &models.OperationType{ID: 6, EntryType: models.CreditEntry, Active: true, Code: models.OpCodeTransferIn}
+
+	// operationTypes expects the transfer operation types to be looked up
+	operationTypes := func() {
+		mockOperationRepo.EXPECT().GetByCode(gomock.Any(), models.OpCodeTransferOut).Return(transferOut, nil)
+		mockOperationRepo.EXPECT().GetByCode(gomock.Any(), models.OpCodeTransferIn).Return(transferIn, nil)
+	}
+
+	// createTransfer expects the transfer to be stored with ID 10
+	createTransfer := func() {
+		mockTransferRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, transfer *models.Transfer) (*models.Transfer, error) {
+				transfer.ID = 10
+				return transfer, nil
+			})
+	}
+
+	t.Run("debit and credit linked to the transfer", func(t *testing.T) {
+		req := &api.CreateTransferRequest{SourceAccountID: 7, DestinationAccountID: 3, Amount: amount(40), Description: "rent"}
+		source := &models.Account{ID: 7, Currency: models.DefaultCurrency, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(100)}
+		destination := &models.Account{ID: 3, Currency: models.DefaultCurrency, Status: models.AccountStatusActive, AvailableCreditLimit: creditLimit(10)}
+		openDebit := &models.Transaction{ID: 1, AccountID: 3, Amount: amount(-15), Balance: amount(-15)}
+
+		operationTypes()
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		// the lowest account ID is locked first whatever the direction of the transfer
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(3)).Return(destination, nil),
+			mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(source, nil),
+		)
+		createTransfer()
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(7)).Return(source, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(3)).Return(destination, nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), source).Return(nil)
+		mockAccountRepo.EXPECT().UpdateCreditLimit(gomock.Any(), destination).Return(nil)
+		mockTransactionRepo.EXPECT().GetOpenDebits(gomock.Any(), int64(3)).Return([]*models.Transaction{openDebit}, nil)
+		mockTransactionRepo.EXPECT().UpdateBalance(gomock.Any(), openDebit).Return(nil)
+		mockTransactionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, txn *models.Transaction) (*models.Transaction, error) {
+				return txn, nil
+			}).Times(2)
+
+		transfer, err := service.CreateTransfer(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), transfer.ID)
+		assert.Equal(t, "rent", transfer.Description)
+		if assert.Len(t, transfer.Transactions, 2) {
+			debit, credit := transfer.Transactions[0], transfer.Transactions[1]
+			assert.Equal(t, int64(7), debit.AccountID)
+			assert.Equal(t, transferOut.ID, debit.OperationTypeID)
+			assert.Equal(t, "-40", debit.Amount.String())
+			assert.Equal(t, int64(10), *debit.TransferID)
+			assert.Equal(t, int64(3), credit.AccountID)
+			assert.Equal(t, transferIn.ID, credit.OperationTypeID)
+			assert.Equal(t, "40", credit.Amount.String())
+			assert.Equal(t, "25", credit.Balance.String())
+			assert.Equal(t, int64(10), *credit.TransferID)
+		}
+		assert.Equal(t, "60", source.AvailableCreditLimit.String())
+		assert.Equal(t, "50", destination.AvailableCreditLimit.String())
+		assert.True(t, openDebit.Balance.IsZero())
+	})
+
+	t.Run("debit exceeding the available credit limit of the source", func(t *testing.T) {
+		req := &api.CreateTransferRequest{SourceAccountID: 1, DestinationAccountID: 2, Amount: amount(40)}
+		source := &models.Account{ID: 1, Currency: models.DefaultCurrency, AvailableCreditLimit: creditLimit(39.99)}
+		destination := &models.Account{ID: 2, Currency: models.DefaultCurrency}
+
+		operationTypes()
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(source, nil).Times(2)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(2)).Return(destination, nil)
+		createTransfer()
+
+		transfer, err := service.CreateTransfer(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrInsufficientLimit, he.Message)
+		assert.Nil(t, transfer)
+	})
+
+	t.Run("accounts in different currencies", func(t *testing.T) {
+		req := &api.CreateTransferRequest{SourceAccountID: 1, DestinationAccountID: 2, Amount: amount(40)}
+
+		operationTypes()
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(&models.Account{ID: 1, Currency: models.DefaultCurrency}, nil)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(2)).Return(&models.Account{ID: 2, Currency: "USD"}, nil)
+
+		transfer, err := service.CreateTransfer(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+		assert.Equal(t, api.ErrTransferCurrency, he.Message)
+		assert.Nil(t, transfer)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		req := &api.CreateTransferRequest{SourceAccountID: 1, DestinationAccountID: 2, Amount: amount(40)}
+
+		operationTypes()
+		mockTransactionRepo.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		mockAccountRepo.EXPECT().GetByIDForUpdate(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+
+		transfer, err := service.CreateTransfer(context.Background(), req)
+		assert.Error(t, err)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, api.ErrAccountNotFound, he.Message)
+		assert.Nil(t, transfer)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, tc := range []struct {
+			req *api.CreateTransferRequest
+			msg string
+		}{
+			{&api.CreateTransferRequest{SourceAccountID: 1, DestinationAccountID: 1, Amount: amount(40)}, api.ErrTransferSameAccount},
+			{&api.CreateTransferRequest{SourceAccountID: 1, DestinationAccountID: 2, Amount: amount(-40)}, api.ErrTransferAmount},
+		} {
+			transfer, err := service.CreateTransfer(context.Background(), tc.req)
+			assert.Error(t, err)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, tc.msg, he.Message)
+			assert.Nil(t, transfer)
+		}
+	})
+}
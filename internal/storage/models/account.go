@@ -2,22 +2,55 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/uptrace/bun"
 )
 
+type AccountStatus string // @name AccountStatus
+
+const (
+	AccountStatusActive  AccountStatus = "active"
+	AccountStatusBlocked AccountStatus = "blocked" // frozen for debits, credits are still accepted
+	AccountStatusClosed  AccountStatus = "closed"  // no activity at all, kept for history
+)
+
+func (as AccountStatus) String() string {
+	return string(as)
+}
+
+func (as AccountStatus) Validate() error {
+	switch as {
+	case AccountStatusActive, AccountStatusBlocked, AccountStatusClosed:
+		return nil
+	default:
+		return fmt.Errorf("invalid account status: %s", as)
+	}
+}
+
 // Account represents customer account.
 type Account struct {
 	bun.BaseModel `bun:"table:accounts" swaggerignore:"true"` // Specifies the table name
 
-	ID        int64     `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
-	DocNum    string    `json:"document_number" bun:"document_number,type:varchar(255)"`                        // Document number
-	CreatedAt time.Time `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
-	UpdatedAt time.Time `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"` // UpdatedAt with default
+	ID                   int64             `json:"id" bun:"id,pk,autoincrement,type:int"`                                                       // Primary key
+	CustomerID           int64             `json:"customer_id" bun:"customer_id,type:int,notnull"`                                              // Foreign key to the owning customer
+	Currency             Currency          `json:"currency" bun:"currency,type:char(3),notnull,default:'BRL'"`                                  // ISO 4217, every transaction of the account is in it
+	AvailableCreditLimit *Money            `json:"available_credit_limit" bun:"available_credit_limit,type:numeric(19,4)" swaggertype:"string"` // Limit left for debits, restored by credits, null when debits are not limited
+	OverLimit            Money             `json:"over_limit" bun:"over_limit,type:numeric(19,4),notnull,default:0" swaggertype:"string"`       // Interest and fees charged beyond a used up limit, paid back by credits first
+	Status               AccountStatus     `json:"status" bun:"status,type:varchar(255),notnull,default:'active'"`                              // active, blocked or closed
+	StatusReason         string            `json:"status_reason,omitempty" bun:"status_reason,type:varchar(255),nullzero"`                      // Reason of the last status change
+	StatusChangedAt      *time.Time        `json:"status_changed_at,omitempty" bun:"status_changed_at,type:timestamptz"`                        // When the status last changed
+	Metadata             map[string]string `json:"metadata,omitempty" bun:"metadata,type:jsonb,notnull,default:'{}'"`                           // Free-form client key/values, e.g. a CRM id
+	ClosingDay           int               `json:"closing_day" bun:"closing_day,type:smallint,notnull,default:1"`                               // Day of the month (1 to 28) the billing cycle closes, at 00:00 UTC
+	DueDays              int               `json:"due_days" bun:"due_days,type:smallint,notnull,default:10"`                                    // Days between the closing of a cycle and the due date of its statement
+	CreatedAt            time.Time         `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"`              // CreatedAt with default
+	UpdatedAt            time.Time         `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"`              // UpdatedAt with default
+	Customer             *Customer         `json:"customer,omitempty" bun:"rel:belongs-to,join:customer_id=id"`                                 // Embedded on request only
 } // @name Account
 
 var _ bun.BeforeAppendModelHook = (*Account)(nil)
+var _ bun.AfterScanRowHook = (*Account)(nil)
 
 func (m *Account) BeforeAppendModel(ctx context.Context, query bun.Query) error {
 	switch query.(type) {
@@ -28,3 +61,30 @@ func (m *Account) BeforeAppendModel(ctx context.Context, query bun.Query) error
 	}
 	return nil
 }
+
+// AfterScanRow has nothing to do on the account itself, but bun only runs the hook of a joined Customer
+// (masking erased personal data) when the parent model has one as well
+func (m *Account) AfterScanRow(ctx context.Context) error {
+	return nil
+}
+
+const (
+	DefaultClosingDay = 1  // billing cycles of accounts opened without a closing day close on the 1st
+	DefaultDueDays    = 10 // statements of accounts opened without due days are due 10 days after closing
+)
+
+// LastClosing is the latest closing of a billing cycle of the account at or before t
+func (m *Account) LastClosing(t time.Time) time.Time {
+	t = t.UTC()
+	closing := time.Date(t.Year(), t.Month(), m.ClosingDay, 0, 0, 0, 0, time.UTC)
+	if closing.After(t) {
+		// the closing day is at most 28, it exists in every month
+		closing = closing.AddDate(0, -1, 0)
+	}
+	return closing
+}
+
+// NextClosing is the first closing of a billing cycle of the account after t
+func (m *Account) NextClosing(t time.Time) time.Time {
+	return m.LastClosing(t).AddDate(0, 1, 0)
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// LateFeeRate is the part of the unpaid minimum payment of a statement charged as a late fee once its due date has passed
+var LateFeeRate = decimal.RequireFromString("0.02")
+
+type AccrualKind string // @name AccrualKind
+
+const (
+	AccrualInterest AccrualKind = "interest" // interest of a day on the overdue debits of the account
+	AccrualLateFee  AccrualKind = "late_fee" // late fee of a statement whose minimum payment was missed
+)
+
+func (k AccrualKind) String() string {
+	return string(k)
+}
+
+// OperationCode is the code of the operation type the charges of the kind are booked with
+func (k AccrualKind) OperationCode() string {
+	if k == AccrualLateFee {
+		return OpCodeLateFee
+	}
+	return OpCodeInterest
+}
+
+// Accrual represents a charge of the accrual job: interest or a late fee booked on an account for a day.
+// There is at most one of each kind per account and day, which is what makes a rerun of the job a no-op.
+type Accrual struct {
+	bun.BaseModel `bun:"table:accruals" swaggerignore:"true"` // Specifies the table name
+
+	ID            int64       `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	AccountID     int64       `json:"account_id" bun:"account_id,type:int,notnull"`                                   // Foreign key to account
+	Kind          AccrualKind `json:"kind" bun:"kind,type:varchar(32),notnull"`                                       // interest or late_fee
+	AccrualDate   time.Time   `json:"accrual_date" bun:"accrual_date,type:date,notnull" swaggertype:"string"`         // Day the charge is for
+	Amount        Money       `json:"amount" bun:"amount,type:numeric(19,4),notnull" swaggertype:"string"`            // Amount charged, positive
+	TransactionID int64       `json:"transaction_id" bun:"transaction_id,type:int,notnull"`                           // Foreign key to the debit booking the charge
+	StatementID   *int64      `json:"statement_id,omitempty" bun:"statement_id,type:int"`                             // Statement whose minimum payment was missed, late fees only
+	CreatedAt     time.Time   `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+} // @name Accrual
+
+var _ bun.BeforeAppendModelHook = (*Accrual)(nil)
+
+func (m *Accrual) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
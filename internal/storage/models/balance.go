@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// Balance represents the aggregated ledger position of an account.
+// It is maintained on every posted transaction, and can be recomputed from scratch from the transactions.
+type Balance struct {
+	bun.BaseModel `bun:"table:account_balances"` // Specifies the table name
+
+	AccountID    int64           `bun:"account_id,pk,type:int"`
+	TotalCredits decimal.Decimal `bun:"total_credits,type:numeric(24,4),notnull"` // sum of positive amounts
+	TotalDebits  decimal.Decimal `bun:"total_debits,type:numeric(24,4),notnull"`  // sum of negative amounts (kept negative)
+	UpdatedAt    time.Time       `bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"`
+}
+
+// Add adds a signed amount to the credits (positive) or the debits (negative) of the balance
+func (m *Balance) Add(amount decimal.Decimal) {
+	if amount.IsPositive() {
+		m.TotalCredits = m.TotalCredits.Add(amount)
+	} else {
+		m.TotalDebits = m.TotalDebits.Add(amount)
+	}
+}
+
+// BalanceDrift represents an account whose stored balance differs from the one computed from its transactions.
+// It is not backed by a table, it is scanned from the consistency check query.
+type BalanceDrift struct {
+	AccountID       int64           `bun:"account_id"`
+	StoredCredits   decimal.Decimal `bun:"stored_credits"`
+	StoredDebits    decimal.Decimal `bun:"stored_debits"`
+	ComputedCredits decimal.Decimal `bun:"computed_credits"`
+	ComputedDebits  decimal.Decimal `bun:"computed_debits"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type Currency string // @name Currency
+
+// DefaultCurrency is used for accounts opened without a currency, every account predating currencies is in BRL
+const DefaultCurrency Currency = "BRL"
+
+// currencyExponents holds the number of decimals (minor unit) of the active ISO 4217 currencies
+var currencyExponents = func() map[Currency]int32 {
+	exponents := map[int32]string{
+		0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF",
+		2: "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV BRL BSD BTN BWP BYN BZD " +
+			"CAD CDF CHE CHF CHW CNY COP COU CRC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD " +
+			"GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL MAD MDL MGA MKD " +
+			"MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD " +
+			"RUB SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD TZS UAH USD " +
+			"USN UYU UZS VED VES WST XCD XCG YER ZAR ZMW ZWG",
+		3: "BHD IQD JOD KWD LYD OMR TND",
+		4: "CLF UYW",
+	}
+	currencies := map[Currency]int32{}
+	for exponent, codes := range exponents {
+		for _, code := range strings.Fields(codes) {
+			currencies[Currency(code)] = exponent
+		}
+	}
+	return currencies
+}()
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+func (c Currency) Validate() error {
+	if _, ok := currencyExponents[c]; !ok {
+		return fmt.Errorf("invalid currency: %s", c)
+	}
+	return nil
+}
+
+// Exponent is the number of decimals of the currency, e.g. 2 for BRL, 0 for JPY and 3 for BHD
+func (c Currency) Exponent() int32 {
+	return currencyExponents[c]
+}
+
+// Fits reports whether amount has no more decimals than the currency allows
+func (c Currency) Fits(amount decimal.Decimal) bool {
+	return amount.Equal(amount.Truncate(c.Exponent()))
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// ErasedPlaceholder replaces the personal data of an erased customer on reads
+const ErasedPlaceholder = "[erased]"
+
+// Customer represents the person (or company) owning accounts.
+type Customer struct {
+	bun.BaseModel `bun:"table:customers" swaggerignore:"true"` // Specifies the table name
+
+	ID        int64      `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	DocNum    string     `json:"document_number" bun:"document_number,type:varchar(255),notnull"`                // CPF or CNPJ without punctuation, unique
+	Name      string     `json:"name,omitempty" bun:"name,type:varchar(255),nullzero"`                           // Full name, unknown for customers created along with an account
+	BirthDate *time.Time `json:"birth_date,omitempty" bun:"birth_date,type:date"`                                // Birth date, unknown for customers created along with an account
+	ErasedAt  *time.Time `json:"erased_at,omitempty" bun:"erased_at,type:timestamptz"`                           // When the personal data was erased (LGPD)
+	ErasedBy  string     `json:"erased_by,omitempty" bun:"erased_by,type:varchar(255),nullzero"`                 // Who requested the erasure
+	CreatedAt time.Time  `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+	UpdatedAt time.Time  `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"` // UpdatedAt with default
+} // @name Customer
+
+var _ bun.BeforeAppendModelHook = (*Customer)(nil)
+var _ bun.AfterScanRowHook = (*Customer)(nil)
+
+func (m *Customer) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	case *bun.UpdateQuery:
+		m.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// AfterScanRow masks the pseudonymized personal data of an erased customer, so it never leaves the DB
+func (m *Customer) AfterScanRow(ctx context.Context) error {
+	if m.ErasedAt != nil {
+		m.DocNum = ErasedPlaceholder
+		m.Name = ErasedPlaceholder
+	}
+	return nil
+}
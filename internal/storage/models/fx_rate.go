@@ -0,0 +1,42 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// FXRate represents the rate converting a base currency into a quote currency during a validity window.
+// Rates are never updated once in use, a new rate closes the window of the previous one so past conversions stay auditable.
+type FXRate struct {
+	bun.BaseModel `bun:"table:fx_rates" swaggerignore:"true"` // Specifies the table name
+
+	ID            int64           `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	BaseCurrency  Currency        `json:"base_currency" bun:"base_currency,type:char(3),notnull"`                         // ISO 4217, currency converted from
+	QuoteCurrency Currency        `json:"quote_currency" bun:"quote_currency,type:char(3),notnull"`                       // ISO 4217, currency converted to
+	Rate          decimal.Decimal `json:"rate" bun:"rate,type:numeric(19,8),notnull" swaggertype:"string"`                // Units of quote currency for one unit of base currency
+	ValidFrom     time.Time       `json:"valid_from" bun:"valid_from,type:timestamptz,notnull"`                           // Start of the validity window, inclusive
+	ValidTo       *time.Time      `json:"valid_to,omitempty" bun:"valid_to,type:timestamptz"`                             // End of the validity window, exclusive, open ended when empty
+	CreatedAt     time.Time       `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+	UpdatedAt     time.Time       `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"` // UpdatedAt with default
+} // @name FXRate
+
+var _ bun.BeforeAppendModelHook = (*FXRate)(nil)
+
+func (m *FXRate) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	case *bun.UpdateQuery:
+		m.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// Convert turns an amount in the base currency into the quote currency, rounded half away from zero
+// to the decimals of the quote currency
+func (m *FXRate) Convert(amount decimal.Decimal) decimal.Decimal {
+	return amount.Mul(m.Rate).Round(m.QuoteCurrency.Exponent())
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// IdempotencyKey stores the response of a request sent with an Idempotency-Key header so retries can be replayed.
+type IdempotencyKey struct {
+	bun.BaseModel `bun:"table:idempotency_keys" swaggerignore:"true"` // Specifies the table name
+
+	Key          string    `bun:"key,pk,type:varchar(255)"`                                      // Idempotency-Key header value
+	RequestHash  string    `bun:"request_hash,type:char(64),notnull"`                            // sha256 of method, path and body of the request
+	ResponseCode int       `bun:"response_code,type:int,nullzero"`                               // zero while the request is still being processed
+	ResponseBody []byte    `bun:"response_body,type:bytea"`                                      // serialized response
+	CreatedAt    time.Time `bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+	ReservedAt   time.Time `bun:"reserved_at,type:timestamptz,notnull"`                          // when the request holding the key started
+	ExpiresAt    time.Time `bun:"expires_at,type:timestamptz,notnull"`                           // key can be reused after this
+}
+
+var _ bun.BeforeAppendModelHook = (*IdempotencyKey)(nil)
+
+func (m *IdempotencyKey) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// InProgress tells if the request holding the key has not finished yet
+func (m *IdempotencyKey) InProgress() bool {
+	return m.ResponseCode == 0
+}
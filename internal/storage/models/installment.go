@@ -0,0 +1,30 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Installment represents one scheduled part of a purchase with installments.
+type Installment struct {
+	bun.BaseModel `bun:"table:installments" swaggerignore:"true"` // Specifies the table name
+
+	ID            int64     `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	TransactionID int64     `json:"transaction_id" bun:"transaction_id,type:int,notnull"`                           // Foreign key to parent purchase
+	Number        int       `json:"number" bun:"number,type:int,notnull"`                                           // Position in the schedule, starting at 1
+	Amount        Money     `json:"amount" bun:"amount,type:numeric(19,4),notnull" swaggertype:"string"`            // Installment amount, same sign as the purchase
+	DueDate       time.Time `json:"due_date" bun:"due_date,type:date,notnull"`                                      // Date the installment is due
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+} // @name Installment
+
+var _ bun.BeforeAppendModelHook = (*Installment)(nil)
+
+func (m *Installment) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
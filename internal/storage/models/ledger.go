@@ -0,0 +1,117 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+type LedgerAccountKind string // @name LedgerAccountKind
+
+const (
+	LedgerCustomer   LedgerAccountKind = "customer"   // what a customer account owes (debit) or is owed (credit)
+	LedgerSettlement LedgerAccountKind = "settlement" // money settled with the outside world (card networks, banks), per currency
+	LedgerFees       LedgerAccountKind = "fees"       // fees and interest earned, per currency
+	LedgerFX         LedgerAccountKind = "fx"         // position taken converting between currencies, per currency
+)
+
+func (k LedgerAccountKind) String() string {
+	return string(k)
+}
+
+// LedgerAccount represents an account of the double-entry books.
+// Customer ledger accounts mirror a customer account, the others exist once per currency.
+type LedgerAccount struct {
+	bun.BaseModel `bun:"table:ledger_accounts" swaggerignore:"true"` // Specifies the table name
+
+	ID        int64             `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	Code      string            `json:"code" bun:"code,type:varchar(64),notnull"`                                       // Natural key, e.g. customer:42 or settlement:BRL
+	Kind      LedgerAccountKind `json:"kind" bun:"kind,type:varchar(32),notnull"`                                       // customer, settlement, fees or fx
+	AccountID *int64            `json:"account_id,omitempty" bun:"account_id,type:int"`                                 // Foreign key to the customer account, customer kind only
+	Currency  Currency          `json:"currency" bun:"currency,type:char(3),notnull"`                                   // ISO 4217, every posting of the account is in it
+	CreatedAt time.Time         `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+} // @name LedgerAccount
+
+var _ bun.BeforeAppendModelHook = (*LedgerAccount)(nil)
+
+func (m *LedgerAccount) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// CustomerLedgerAccount is the ledger account mirroring a customer account
+func CustomerLedgerAccount(accountID int64, currency Currency) *LedgerAccount {
+	return &LedgerAccount{Code: fmt.Sprintf("%s:%d", LedgerCustomer, accountID), Kind: LedgerCustomer, AccountID: &accountID, Currency: currency}
+}
+
+// SystemLedgerAccount is the settlement, fees or fx ledger account of a currency
+func SystemLedgerAccount(kind LedgerAccountKind, currency Currency) *LedgerAccount {
+	return &LedgerAccount{Code: fmt.Sprintf("%s:%s", kind, currency), Kind: kind, Currency: currency}
+}
+
+// JournalEntry represents the booking of a posted transaction in the double-entry books.
+// Its postings sum up to zero in every currency, which the DB checks when the surrounding transaction commits.
+type JournalEntry struct {
+	bun.BaseModel `bun:"table:journal_entries" swaggerignore:"true"` // Specifies the table name
+
+	ID            int64     `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	TransactionID int64     `json:"transaction_id" bun:"transaction_id,type:int,notnull"`                           // Foreign key to the booked transaction
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+
+	Postings []*Posting `json:"postings" bun:"rel:has-many,join:id=journal_entry_id"` // Debits and credits of the entry
+} // @name JournalEntry
+
+var _ bun.BeforeAppendModelHook = (*JournalEntry)(nil)
+
+func (m *JournalEntry) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// Balanced tells whether the postings of the entry sum up to zero in every currency
+func (m *JournalEntry) Balanced() bool {
+	sums := map[Currency]decimal.Decimal{}
+	for _, posting := range m.Postings {
+		sums[posting.Currency] = sums[posting.Currency].Add(posting.Amount.Decimal)
+	}
+	for _, sum := range sums {
+		if !sum.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// Posting represents one debit (positive amount) or credit (negative amount) of a journal entry on a ledger account.
+type Posting struct {
+	bun.BaseModel `bun:"table:postings" swaggerignore:"true"` // Specifies the table name
+
+	ID              int64    `json:"id" bun:"id,pk,autoincrement,type:int"`                               // Primary key
+	JournalEntryID  int64    `json:"journal_entry_id" bun:"journal_entry_id,type:int,notnull"`            // Foreign key to the journal entry
+	LedgerAccountID int64    `json:"ledger_account_id" bun:"ledger_account_id,type:int,notnull"`          // Foreign key to the ledger account
+	Amount          Money    `json:"amount" bun:"amount,type:numeric(19,4),notnull" swaggertype:"string"` // Positive for a debit, negative for a credit
+	Currency        Currency `json:"currency" bun:"currency,type:char(3),notnull"`                        // ISO 4217, the currency of the ledger account
+
+	LedgerAccount *LedgerAccount `json:"ledger_account,omitempty" bun:"rel:belongs-to,join:ledger_account_id=id"` // Ledger account posted to
+} // @name Posting
+
+// LedgerAccountBalance represents the totals posted to a ledger account, a line of the trial balance.
+// It is not backed by a table, it is scanned from an aggregate query over postings.
+type LedgerAccountBalance struct {
+	LedgerAccountID int64             `json:"ledger_account_id" bun:"ledger_account_id"`
+	Code            string            `json:"code" bun:"code"`
+	Kind            LedgerAccountKind `json:"kind" bun:"kind"`
+	Currency        Currency          `json:"currency" bun:"currency"`
+	Debits          Money             `json:"debits" bun:"debits" swaggertype:"string"`   // sum of positive amounts
+	Credits         Money             `json:"credits" bun:"credits" swaggertype:"string"` // sum of negative amounts, as a positive number
+	Balance         Money             `json:"balance" bun:"balance" swaggertype:"string"` // debits minus credits
+} // @name LedgerAccountBalance
@@ -0,0 +1,6 @@
+package models
+
+import "github.com/akhiltak/pismo-api/pkg/money"
+
+// Money is the type of the NUMERIC(19,4) amount columns, shared with the API so amounts never go through float64
+type Money = money.Money
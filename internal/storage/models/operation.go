@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/uptrace/bun"
 )
 
@@ -15,6 +16,17 @@ const (
 	DebitEntry  EntryType = "debit"
 )
 
+// Codes of the operation types booked by the service itself, seeded by the migrations
+const (
+	OpCodeTransferOut = "transfer_out"
+	OpCodeTransferIn  = "transfer_in"
+	OpCodeInterest    = "interest"
+	OpCodeLateFee     = "late_fee"
+)
+
+// InterestDays is the number of days a monthly interest rate is spread over to accrue it daily
+const InterestDays = 30
+
 func (et EntryType) String() string {
 	return string(et)
 }
@@ -32,11 +44,15 @@ func (et EntryType) Validate() error {
 type OperationType struct {
 	bun.BaseModel `bun:"table:operation_types" swaggerignore:"true"` // Specifies the table name
 
-	ID          int64     `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
-	Description string    `json:"description" bun:"description,type:varchar(255)"`                                // Description
-	EntryType   EntryType `json:"type" bun:"entry_type,type:varchar(255)"`                                        // type (credit/debit)
-	CreatedAt   time.Time `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
-	UpdatedAt   time.Time `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"` // UpdatedAt with default
+	ID           int64            `json:"id" bun:"id,pk,autoincrement,type:int"`                                              // Primary key
+	Description  string           `json:"description" bun:"description,type:varchar(255)"`                                    // Description
+	EntryType    EntryType        `json:"type" bun:"entry_type,type:varchar(255)"`                                            // type (credit/debit)
+	Installments bool             `json:"installments" bun:"installments,notnull"`                                            // whether the amount can be split in installments
+	InterestRate *decimal.Decimal `json:"interest_rate,omitempty" bun:"interest_rate,type:numeric(7,6)" swaggertype:"string"` // monthly rate charged on the overdue debits of the type, none when nil
+	Active       bool             `json:"active" bun:"active,notnull,default:true"`                                           // inactive types cannot be used for new transactions
+	Code         string           `json:"code,omitempty" bun:"code,type:varchar(64),nullzero"`                                // set on the types reserved to the service, e.g. transfers
+	CreatedAt    time.Time        `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"`     // CreatedAt with default
+	UpdatedAt    time.Time        `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"`     // UpdatedAt with default
 } // @name OperationType
 
 var _ bun.BeforeAppendModelHook = (*OperationType)(nil)
@@ -50,3 +66,16 @@ func (m *OperationType) BeforeAppendModel(ctx context.Context, query bun.Query)
 	}
 	return nil
 }
+
+// Fee tells whether the type is one of the charges of the service (interest, late fees), booked against the fees ledger account
+func (m *OperationType) Fee() bool {
+	return m.Code == OpCodeInterest || m.Code == OpCodeLateFee
+}
+
+// DailyInterest is the interest of one day on what is still owed of a debit of the type, not rounded
+func (m *OperationType) DailyInterest(owed decimal.Decimal) decimal.Decimal {
+	if m.InterestRate == nil {
+		return decimal.Zero
+	}
+	return owed.Abs().Mul(*m.InterestRate).Div(decimal.NewFromInt(InterestDays))
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type ScheduleFrequency string // @name ScheduleFrequency
+
+const (
+	FrequencyOnce    ScheduleFrequency = "once"    // a single future-dated transaction, at start_at
+	FrequencyDaily   ScheduleFrequency = "daily"   // every day at the time of start_at
+	FrequencyWeekly  ScheduleFrequency = "weekly"  // every week on the weekday and time of start_at
+	FrequencyMonthly ScheduleFrequency = "monthly" // every month on the day of start_at, the last day of shorter months
+	FrequencyCron    ScheduleFrequency = "cron"    // at every time matching cron_expression (UTC) from start_at on
+)
+
+func (f ScheduleFrequency) String() string {
+	return string(f)
+}
+
+func (f ScheduleFrequency) Validate() error {
+	switch f {
+	case FrequencyOnce, FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyCron:
+		return nil
+	default:
+		return fmt.Errorf("invalid frequency: %s", f)
+	}
+}
+
+type ScheduleStatus string // @name ScheduleStatus
+
+const (
+	ScheduleActive    ScheduleStatus = "active"    // occurrences are still to be booked
+	ScheduleCompleted ScheduleStatus = "completed" // the last occurrence was booked, end_at or max_occurrences reached
+	ScheduleCancelled ScheduleStatus = "cancelled" // cancelled by the client, no more occurrences
+)
+
+type ExecutionStatus string // @name ExecutionStatus
+
+const (
+	ExecutionSucceeded ExecutionStatus = "succeeded"
+	ExecutionFailed    ExecutionStatus = "failed"  // rejected like the request would have been, e.g. insufficient credit limit
+	ExecutionSkipped   ExecutionStatus = "skipped" // missed by more than the max lateness of the scheduler, e.g. while it was down
+)
+
+// ScheduledTransaction represents a transaction booked later by the scheduler of the server, once or recurring.
+type ScheduledTransaction struct {
+	bun.BaseModel `bun:"table:scheduled_transactions" swaggerignore:"true"` // Specifies the table name
+
+	ID              int64             `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	AccountID       int64             `json:"account_id" bun:"account_id,type:int,notnull"`                                   // Foreign key to account
+	OperationTypeID int64             `json:"operation_type_id" bun:"operation_type_id,type:int,notnull"`                     // Foreign key to OperationType
+	Amount          Money             `json:"amount" bun:"amount,type:numeric(19,4),notnull" swaggertype:"string"`            // Amount of every occurrence, positive, signed by the operation type
+	Currency        Currency          `json:"currency,omitempty" bun:"currency,type:char(3),nullzero"`                        // ISO 4217, the currency of the account when empty
+	Frequency       ScheduleFrequency `json:"frequency" bun:"frequency,type:varchar(32),notnull"`                             // once, daily, weekly, monthly or cron
+	CronExpression  string            `json:"cron_expression,omitempty" bun:"cron_expression,type:varchar(255),nullzero"`     // 5 field cron expression, cron frequency only
+	StartAt         time.Time         `json:"start_at" bun:"start_at,type:timestamptz,notnull"`                               // First occurrence, or the earliest one with a cron expression
+	EndAt           *time.Time        `json:"end_at,omitempty" bun:"end_at,type:timestamptz"`                                 // No occurrence after it when set
+	MaxOccurrences  *int              `json:"max_occurrences,omitempty" bun:"max_occurrences,type:int"`                       // No more occurrences than this when set
+	Occurrences     int               `json:"occurrences" bun:"occurrences,type:int,notnull,default:0"`                       // Occurrences booked so far, succeeded, failed or skipped
+	NextRunAt       *time.Time        `json:"next_run_at,omitempty" bun:"next_run_at,type:timestamptz"`                       // Next occurrence to book, none once completed or cancelled
+	Status          ScheduleStatus    `json:"status" bun:"status,type:varchar(32),notnull,default:'active'"`                  // active, completed or cancelled
+	CreatedAt       time.Time         `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+	UpdatedAt       time.Time         `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"` // UpdatedAt with default
+
+	Executions []*ScheduledExecution `json:"executions,omitempty" bun:"rel:has-many,join:id=scheduled_transaction_id"` // Result of every occurrence booked
+} // @name ScheduledTransaction
+
+var _ bun.BeforeAppendModelHook = (*ScheduledTransaction)(nil)
+
+func (m *ScheduledTransaction) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	case *bun.UpdateQuery:
+		m.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// ScheduledExecution represents the result of one occurrence of a scheduled transaction.
+type ScheduledExecution struct {
+	bun.BaseModel `bun:"table:scheduled_transaction_executions" swaggerignore:"true"` // Specifies the table name
+
+	ID                     int64           `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	ScheduledTransactionID int64           `json:"scheduled_transaction_id" bun:"scheduled_transaction_id,type:int,notnull"`       // Foreign key to the scheduled transaction
+	ScheduledFor           time.Time       `json:"scheduled_for" bun:"scheduled_for,type:timestamptz,notnull"`                     // Occurrence booked, unique per scheduled transaction
+	Status                 ExecutionStatus `json:"status" bun:"status,type:varchar(32),notnull"`                                   // succeeded, failed or skipped
+	TransactionID          *int64          `json:"transaction_id,omitempty" bun:"transaction_id,type:int"`                         // Transaction booked, when succeeded
+	Error                  string          `json:"error,omitempty" bun:"error,type:varchar(255),nullzero"`                         // Why the transaction was rejected, when failed
+	CreatedAt              time.Time       `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+} // @name ScheduledExecution
+
+var _ bun.BeforeAppendModelHook = (*ScheduledExecution)(nil)
+
+func (m *ScheduledExecution) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/akhiltak/pismo-api/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// MinimumPaymentRate is the part of what is owed at the closing of a cycle that has to be paid by the due date
+var MinimumPaymentRate = decimal.RequireFromString("0.15")
+
+// Statement represents a closed billing cycle of an account: the posted transactions of the cycle are frozen into it
+// along with the balances, the minimum payment and the due date, none of which change afterwards.
+type Statement struct {
+	bun.BaseModel `bun:"table:statements" swaggerignore:"true"` // Specifies the table name
+
+	ID             int64     `json:"id" bun:"id,pk,autoincrement,type:int"`                                                 // Primary key
+	AccountID      int64     `json:"account_id" bun:"account_id,type:int,notnull"`                                          // Foreign key to account
+	Currency       Currency  `json:"currency" bun:"currency,type:char(3),notnull"`                                          // ISO 4217, the currency of the account
+	PeriodStart    time.Time `json:"period_start" bun:"period_start,type:timestamptz,notnull"`                              // Start of the cycle, inclusive (end of the previous one)
+	PeriodEnd      time.Time `json:"period_end" bun:"period_end,type:timestamptz,notnull"`                                  // Closing of the cycle, exclusive
+	DueDate        time.Time `json:"due_date" bun:"due_date,type:date,notnull" swaggertype:"string" format:"date"`          // Date the minimum payment is due
+	OpeningBalance Money     `json:"opening_balance" bun:"opening_balance,type:numeric(24,4),notnull" swaggertype:"string"` // Closing balance of the previous statement, negative when owed
+	TotalCredits   Money     `json:"total_credits" bun:"total_credits,type:numeric(24,4),notnull" swaggertype:"string"`     // Sum of the credits of the cycle
+	TotalDebits    Money     `json:"total_debits" bun:"total_debits,type:numeric(24,4),notnull" swaggertype:"string"`       // Sum of the debits of the cycle (absolute value)
+	ClosingBalance Money     `json:"closing_balance" bun:"closing_balance,type:numeric(24,4),notnull" swaggertype:"string"` // Opening balance plus credits minus debits, negative when owed
+	MinimumPayment Money     `json:"minimum_payment" bun:"minimum_payment,type:numeric(24,4),notnull" swaggertype:"string"` // Part of the owed closing balance to pay by the due date
+	CreatedAt      time.Time `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"`        // CreatedAt with default
+	UpdatedAt      time.Time `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"`        // UpdatedAt with default
+
+	Transactions []*Transaction `json:"transactions,omitempty" bun:"rel:has-many,join:id=statement_id"` // Posted transactions of the cycle, on GET /statements/:id
+} // @name Statement
+
+var _ bun.BeforeAppendModelHook = (*Statement)(nil)
+
+func (m *Statement) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	case *bun.UpdateQuery:
+		m.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// Close sets the totals of the cycle from the frozen transactions, the closing balance and the minimum payment:
+// a share of what is owed rounded up to the decimals of the currency, nothing when nothing is owed
+func (m *Statement) Close(frozen *Balance) {
+	m.TotalCredits = money.New(frozen.TotalCredits)
+	m.TotalDebits = money.New(frozen.TotalDebits.Abs())
+	m.ClosingBalance = money.New(m.OpeningBalance.Add(frozen.TotalCredits).Add(frozen.TotalDebits))
+	m.MinimumPayment = Money{}
+	if m.ClosingBalance.IsNegative() {
+		m.MinimumPayment = money.New(m.ClosingBalance.Neg().Mul(MinimumPaymentRate).RoundCeil(m.Currency.Exponent()))
+	}
+}
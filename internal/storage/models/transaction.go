@@ -12,18 +12,24 @@ import (
 type TxnStatus string // @name TxnStatus
 
 const (
-	TxnStatusPending   TxnStatus = "pending"
-	TxnStatusCompleted TxnStatus = "completed"
-	TxnStatusFailed    TxnStatus = "failed"
+	TxnStatusPending           TxnStatus = "pending"
+	TxnStatusCompleted         TxnStatus = "completed"
+	TxnStatusFailed            TxnStatus = "failed"
+	TxnStatusReversed          TxnStatus = "reversed"           // fully reversed/refunded by compensating transactions
+	TxnStatusPartiallyRefunded TxnStatus = "partially_refunded" // part of the amount refunded by compensating transactions
 )
 
+// PostedStatuses are the statuses of transactions whose amount is part of the account balance.
+// Reversed transactions stay posted, their compensating transactions offset them.
+var PostedStatuses = []TxnStatus{TxnStatusCompleted, TxnStatusReversed, TxnStatusPartiallyRefunded}
+
 func (ts TxnStatus) String() string {
 	return string(ts)
 }
 
 func (ts TxnStatus) Validate() error {
 	switch ts {
-	case TxnStatusPending, TxnStatusCompleted, TxnStatusFailed:
+	case TxnStatusPending, TxnStatusCompleted, TxnStatusFailed, TxnStatusReversed, TxnStatusPartiallyRefunded:
 		return nil
 	default:
 		return fmt.Errorf("invalid status: %s", ts)
@@ -34,13 +40,25 @@ func (ts TxnStatus) Validate() error {
 type Transaction struct {
 	bun.BaseModel `bun:"table:transactions" swaggerignore:"true"` // Specifies the table name
 
-	ID              int64           `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
-	AccountID       int64           `json:"account_id" bun:"account_id,type:int,notnull"`                                   // Foreign key to account
-	OperationTypeID int64           `json:"operationTypeID" bun:"operation_type_id,type:int,notnull"`                       // Foreign key to OperationType
-	Amount          decimal.Decimal `json:"amount" bun:"amount,type:float8,notnull"`                                        // Transaction amount
-	Status          TxnStatus       `json:"status" bun:"status,type:varchar(255),notnull"`                                  // status
-	EventDate       time.Time       `json:"event_date" bun:"event_date,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default, called EventDate due to assignment instructions
-	UpdatedAt       time.Time       `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"` // UpdatedAt with default
+	ID               int64            `json:"id" bun:"id,pk,autoincrement,type:int"`                                                   // Primary key
+	AccountID        int64            `json:"account_id" bun:"account_id,type:int,notnull"`                                            // Foreign key to account
+	OperationTypeID  int64            `json:"operationTypeID" bun:"operation_type_id,type:int,notnull"`                                // Foreign key to OperationType
+	Amount           Money            `json:"amount" bun:"amount,type:numeric(19,4),notnull" swaggertype:"string"`                     // Transaction amount
+	Balance          Money            `json:"balance" bun:"balance,type:numeric(19,4),notnull" swaggertype:"string"`                   // Remaining amount not yet discharged (negative for open debits)
+	Currency         Currency         `json:"currency" bun:"currency,type:char(3),notnull"`                                            // ISO 4217, always the currency of the account
+	OriginalAmount   *Money           `json:"original_amount,omitempty" bun:"original_amount,type:numeric(19,4)" swaggertype:"string"` // Amount in the currency of a purchase made in another currency, before conversion
+	OriginalCurrency Currency         `json:"original_currency,omitempty" bun:"original_currency,type:char(3),nullzero"`               // ISO 4217 currency of the purchase, only when it differs from the account
+	FXRate           *decimal.Decimal `json:"fx_rate,omitempty" bun:"fx_rate,type:numeric(19,8)" swaggertype:"string"`                 // Rate applied, Amount is OriginalAmount times FXRate rounded to the account currency
+	FXRateID         *int64           `json:"fx_rate_id,omitempty" bun:"fx_rate_id,type:int"`                                          // FX rate record the conversion used
+	TransferID       *int64           `json:"transfer_id,omitempty" bun:"transfer_id,type:int"`                                        // Transfer this debit or credit is one side of
+	ReversedTxnID    *int64           `json:"reversed_transaction_id,omitempty" bun:"reversed_transaction_id,type:int"`                // Transaction compensated by this one (reversals and refunds only)
+	StatementID      *int64           `json:"statement_id,omitempty" bun:"statement_id,type:int"`                                      // Statement the transaction is frozen into once its billing cycle is closed
+	Status           TxnStatus        `json:"status" bun:"status,type:varchar(255),notnull"`                                           // status
+	EventDate        time.Time        `json:"event_date" bun:"event_date,type:timestamptz,notnull,default:current_timestamp"`          // CreatedAt with default, called EventDate due to assignment instructions
+	UpdatedAt        time.Time        `json:"updated_at" bun:"updated_at,type:timestamptz,notnull,default:current_timestamp"`          // UpdatedAt with default
+
+	OperationType *OperationType `json:"operation_type,omitempty" bun:"rel:belongs-to,join:operation_type_id=id"` // Embedded on request only
+	Installments  []*Installment `json:"installments,omitempty" bun:"rel:has-many,join:id=transaction_id"`        // Installment schedule of a purchase with installments
 } // @name Transaction
 
 var _ bun.BeforeAppendModelHook = (*Transaction)(nil)
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Transfer represents money moved between two accounts, booked as a debit on the source and a credit on the destination.
+type Transfer struct {
+	bun.BaseModel `bun:"table:transfers" swaggerignore:"true"` // Specifies the table name
+
+	ID                   int64     `json:"id" bun:"id,pk,autoincrement,type:int"`                                          // Primary key
+	SourceAccountID      int64     `json:"source_account_id" bun:"source_account_id,type:int,notnull"`                     // Foreign key to the debited account
+	DestinationAccountID int64     `json:"destination_account_id" bun:"destination_account_id,type:int,notnull"`           // Foreign key to the credited account
+	Amount               Money     `json:"amount" bun:"amount,type:numeric(19,4),notnull" swaggertype:"string"`            // Amount moved, always positive
+	Currency             Currency  `json:"currency" bun:"currency,type:char(3),notnull"`                                   // ISO 4217, the currency of both accounts
+	Description          string    `json:"description,omitempty" bun:"description,type:varchar(255),nullzero"`             // Free text given by the client
+	CreatedAt            time.Time `json:"created_at" bun:"created_at,type:timestamptz,notnull,default:current_timestamp"` // CreatedAt with default
+
+	Transactions []*Transaction `json:"transactions,omitempty" bun:"rel:has-many,join:id=transfer_id"` // The debit and the credit booked for the transfer
+} // @name Transfer
+
+var _ bun.BeforeAppendModelHook = (*Transfer)(nil)
+
+func (m *Transfer) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
@@ -9,8 +9,15 @@ import (
 
 type Account interface {
 	Create(context.Context, *models.Account) (*models.Account, error)
-	GetAllAccounts(context.Context) ([]*models.Account, error)
+	List(context.Context, *ListQuery) ([]*models.Account, *Cursor, error)
 	GetByID(context.Context, int64, bool) (*models.Account, error)
+	GetByIDForUpdate(context.Context, int64) (*models.Account, error)
+	GetByCustomerID(context.Context, int64, *ListQuery) ([]*models.Account, *Cursor, error)
+	GetByCustomerIDForUpdate(context.Context, int64) ([]*models.Account, error)
+	UpdateCreditLimit(context.Context, *models.Account) error
+	UpdateStatus(context.Context, *models.Account) error
+	UpdateMetadata(context.Context, *models.Account) error
+	ClearStatusReason(context.Context, *models.Account) error
 }
 
 type account struct {
@@ -25,12 +32,61 @@ func (a *account) Create(ctx context.Context, model *models.Account) (*models.Ac
 	return a.baseRepo.Insert(ctx, model)
 }
 
-// GetAllAccounts fetches all customer Accounts
-func (a *account) GetAllAccounts(ctx context.Context) ([]*models.Account, error) {
-	return a.baseRepo.GetAll(ctx, "")
+// List fetches one page of customer Accounts matching the query
+func (a *account) List(ctx context.Context, q *ListQuery) ([]*models.Account, *Cursor, error) {
+	return a.baseRepo.List(ctx, q)
 }
 
 // GetByID fetches an Account by ID
 func (a *account) GetByID(ctx context.Context, id int64, associations bool) (*models.Account, error) {
-	return a.baseRepo.FindByID(ctx, id, "")
+	relation := ""
+	if associations {
+		relation = "Customer"
+	}
+	return a.baseRepo.FindByID(ctx, id, relation)
+}
+
+// GetByIDForUpdate fetches an Account by ID and locks its row until the surrounding transaction ends
+func (a *account) GetByIDForUpdate(ctx context.Context, id int64) (*models.Account, error) {
+	return a.baseRepo.LockByID(ctx, id)
+}
+
+// GetByCustomerID fetches one page of the Accounts of a Customer
+func (a *account) GetByCustomerID(ctx context.Context, customerID int64, q *ListQuery) ([]*models.Account, *Cursor, error) {
+	return a.baseRepo.FindByColumn(ctx, customerID, "customer_id", q)
+}
+
+// GetByCustomerIDForUpdate fetches all the Accounts of a Customer ordered by ID and locks their rows
+// until the surrounding transaction ends
+func (a *account) GetByCustomerIDForUpdate(ctx context.Context, customerID int64) ([]*models.Account, error) {
+	var accounts []*models.Account
+	if err := a.conn(ctx).NewSelect().Model(&accounts).
+		Where("customer_id = ?", customerID).
+		OrderExpr("id ASC").
+		For("UPDATE").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// UpdateCreditLimit persists the available credit limit of an Account along with what it owes over it
+func (a *account) UpdateCreditLimit(ctx context.Context, model *models.Account) error {
+	return a.baseRepo.UpdateColumns(ctx, model, "available_credit_limit", "over_limit")
+}
+
+// UpdateStatus persists the status of an Account along with the reason and time of the change
+func (a *account) UpdateStatus(ctx context.Context, model *models.Account) error {
+	return a.baseRepo.UpdateColumns(ctx, model, "status", "status_reason", "status_changed_at")
+}
+
+// UpdateMetadata persists the metadata of an Account, replacing the previous one
+func (a *account) UpdateMetadata(ctx context.Context, model *models.Account) error {
+	return a.baseRepo.UpdateColumns(ctx, model, "metadata")
+}
+
+// ClearStatusReason drops the free text reason of the last status change, it may hold personal data
+func (a *account) ClearStatusReason(ctx context.Context, model *models.Account) error {
+	model.StatusReason = ""
+	return a.baseRepo.UpdateColumns(ctx, model, "status_reason")
 }
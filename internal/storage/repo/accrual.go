@@ -0,0 +1,36 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type Accrual interface {
+	Create(context.Context, *models.Accrual) (*models.Accrual, error)
+	Exists(context.Context, int64, models.AccrualKind, time.Time) (bool, error)
+}
+
+type accrual struct {
+	*baseRepo[models.Accrual]
+}
+
+func NewAccrualRepo(db bun.IDB) Accrual {
+	return &accrual{baseRepo: newBaseRepo[models.Accrual](db)}
+}
+
+func (a *accrual) Create(ctx context.Context, model *models.Accrual) (*models.Accrual, error) {
+	return a.baseRepo.Insert(ctx, model)
+}
+
+// Exists tells whether a charge of the kind was already booked on the account for the day
+func (a *accrual) Exists(ctx context.Context, accountID int64, kind models.AccrualKind, day time.Time) (bool, error) {
+	return a.conn(ctx).NewSelect().
+		Model((*models.Accrual)(nil)).
+		Where("account_id = ?", accountID).
+		Where("kind = ?", kind).
+		Where("accrual_date = ?", day.Format(time.DateOnly)).
+		Exists(ctx)
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// txCtxKey is the context key under which RunInTx stores the running transaction
+type txCtxKey struct{}
+
 type baseRepo[T any] struct {
 	db bun.IDB
 }
@@ -17,15 +20,33 @@ func newBaseRepo[T any](db bun.IDB) *baseRepo[T] {
 	}
 }
 
+// conn returns the transaction started by RunInTx if ctx carries one, otherwise the db handle.
+// This lets every repo join a transaction started by any other repo sharing the same db.
+func (in *baseRepo[T]) conn(ctx context.Context) bun.IDB {
+	if tx, ok := ctx.Value(txCtxKey{}).(bun.Tx); ok {
+		return tx
+	}
+	return in.db
+}
+
 func (in *baseRepo[T]) Insert(ctx context.Context, model *T) (*T, error) {
-	if _, err := in.db.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+	if _, err := in.conn(ctx).NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
 		return nil, err
 	}
 	return model, nil
 }
 
 func (in *baseRepo[T]) Update(ctx context.Context, model *T) error {
-	if _, err := in.db.NewUpdate().Model(model).WherePK().Exec(ctx); err != nil {
+	if _, err := in.conn(ctx).NewUpdate().Model(model).WherePK().Exec(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateColumns updates only the given columns of the model (updated_at is always included)
+func (in *baseRepo[T]) UpdateColumns(ctx context.Context, model *T, columns ...string) error {
+	columns = append(columns, "updated_at")
+	if _, err := in.conn(ctx).NewUpdate().Model(model).Column(columns...).WherePK().Exec(ctx); err != nil {
 		return err
 	}
 	return nil
@@ -33,27 +54,30 @@ func (in *baseRepo[T]) Update(ctx context.Context, model *T) error {
 
 func (in *baseRepo[T]) FindByID(ctx context.Context, id int64, relation string) (*T, error) {
 	model := new(T)
-	query := in.db.NewSelect().Model(model)
+	query := in.conn(ctx).NewSelect().Model(model)
 	if relation != "" {
 		query = query.Relation(relation)
 	}
-	if err := query.Where("id = ?", id).Scan(ctx); err != nil {
+	if err := query.Where("?TableAlias.id = ?", id).Scan(ctx); err != nil {
 		// CHECK for the error type if it's not found and return 404.
 		return nil, err
 	}
 	return model, nil
 }
 
-func (in *baseRepo[T]) FindByColumn(ctx context.Context, id int64, filterColumnName, relation string) ([]*T, error) {
-	var models []*T
-	query := in.db.NewSelect().Model(&models)
-	if relation != "" {
-		query = query.Relation(relation)
-	}
-	if err := query.Where("? = ?", bun.Ident(filterColumnName), id).Scan(ctx); err != nil {
+// LockByID fetches a row by ID and locks it (SELECT ... FOR UPDATE) until the surrounding transaction ends
+// Should only be used inside RunInTx, otherwise the lock is released right away
+func (in *baseRepo[T]) LockByID(ctx context.Context, id int64) (*T, error) {
+	model := new(T)
+	if err := in.conn(ctx).NewSelect().Model(model).Where("id = ?", id).For("UPDATE").Scan(ctx); err != nil {
 		return nil, err
 	}
-	return models, nil
+	return model, nil
+}
+
+// FindByColumn fetches one page of rows whose column equals id, sorted, paginated and with relations as set on the query
+func (in *baseRepo[T]) FindByColumn(ctx context.Context, id int64, filterColumnName string, q *ListQuery) ([]*T, *Cursor, error) {
+	return in.List(ctx, q.Eq(filterColumnName, id))
 }
 
 func (in *baseRepo[T]) Delete(ctx context.Context, id int64) error {
@@ -62,7 +86,7 @@ func (in *baseRepo[T]) Delete(ctx context.Context, id int64) error {
 		// CHECK for the error type if it's not found and return 404.
 		return err
 	}
-	if _, err := in.db.NewDelete().Model(model).WherePK().Exec(ctx); err != nil {
+	if _, err := in.conn(ctx).NewDelete().Model(model).WherePK().Exec(ctx); err != nil {
 		return err
 	}
 	return nil
@@ -70,7 +94,7 @@ func (in *baseRepo[T]) Delete(ctx context.Context, id int64) error {
 
 func (in *baseRepo[T]) GetAll(ctx context.Context, relation string) ([]*T, error) {
 	var models []*T
-	query := in.db.NewSelect().Model(&models)
+	query := in.conn(ctx).NewSelect().Model(&models)
 
 	if relation != "" {
 		query = query.Relation(relation)
@@ -82,8 +106,24 @@ func (in *baseRepo[T]) GetAll(ctx context.Context, relation string) ([]*T, error
 	return models, nil
 }
 
+// List fetches one page of rows matching the query along with the cursor of the next page (nil on the last page)
+func (in *baseRepo[T]) List(ctx context.Context, q *ListQuery) ([]*T, *Cursor, error) {
+	var models []*T
+	if err := q.apply(in.conn(ctx).NewSelect().Model(&models)).Scan(ctx); err != nil {
+		return nil, nil, err
+	}
+	models, next := nextCursor(in.db, q, models)
+	return models, next, nil
+}
+
+// RunInTx runs f inside a DB transaction and binds the transaction to the ctx passed to f,
+// so repo calls made with that ctx are part of the same transaction.
+// If ctx already carries a transaction, f simply joins it.
 func (in *baseRepo[T]) RunInTx(ctx context.Context, opts *sql.TxOptions, f func(ctx context.Context, tx bun.Tx) error) error {
-	return in.db.RunInTx(ctx, opts, func(ctx context.Context, tx bun.Tx) error {
+	if tx, ok := ctx.Value(txCtxKey{}).(bun.Tx); ok {
 		return f(ctx, tx)
+	}
+	return in.db.RunInTx(ctx, opts, func(ctx context.Context, tx bun.Tx) error {
+		return f(context.WithValue(ctx, txCtxKey{}, tx), tx)
 	})
 }
@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type Customer interface {
+	Create(context.Context, *models.Customer) (*models.Customer, error)
+	FindOrCreateByDocument(context.Context, *models.Customer) (*models.Customer, error)
+	GetByID(context.Context, int64) (*models.Customer, error)
+	GetByIDForUpdate(context.Context, int64) (*models.Customer, error)
+	Erase(context.Context, *models.Customer) error
+}
+
+type customer struct {
+	*baseRepo[models.Customer]
+}
+
+func NewCustomerRepo(db bun.IDB) Customer {
+	return &customer{baseRepo: newBaseRepo[models.Customer](db)}
+}
+
+func (c *customer) Create(ctx context.Context, model *models.Customer) (*models.Customer, error) {
+	return c.baseRepo.Insert(ctx, model)
+}
+
+// FindOrCreateByDocument inserts the Customer unless one with the same document number exists, in which case
+// that one is returned untouched. The upsert keeps concurrent account openings for a new document from racing.
+func (c *customer) FindOrCreateByDocument(ctx context.Context, model *models.Customer) (*models.Customer, error) {
+	// DO NOTHING would return no row on conflict, the no-op update makes RETURNING yield the existing one
+	if _, err := c.conn(ctx).NewInsert().Model(model).
+		On("CONFLICT (document_number) DO UPDATE").
+		Set("document_number = EXCLUDED.document_number").
+		Returning("*").
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// GetByID fetches a Customer by ID
+func (c *customer) GetByID(ctx context.Context, id int64) (*models.Customer, error) {
+	return c.baseRepo.FindByID(ctx, id, "")
+}
+
+// GetByIDForUpdate fetches a Customer by ID and locks its row until the surrounding transaction ends
+func (c *customer) GetByIDForUpdate(ctx context.Context, id int64) (*models.Customer, error) {
+	return c.baseRepo.LockByID(ctx, id)
+}
+
+// Erase persists the pseudonymized personal data of a Customer along with who requested the erasure and when
+func (c *customer) Erase(ctx context.Context, model *models.Customer) error {
+	return c.baseRepo.UpdateColumns(ctx, model, "document_number", "name", "birth_date", "erased_at", "erased_by")
+}
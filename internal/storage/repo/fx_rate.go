@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type FXRate interface {
+	Create(context.Context, *models.FXRate) (*models.FXRate, error)
+	List(context.Context, *ListQuery) ([]*models.FXRate, *Cursor, error)
+	GetValidAt(context.Context, models.Currency, models.Currency, time.Time) (*models.FXRate, error)
+	GetOpenForUpdate(context.Context, models.Currency, models.Currency) (*models.FXRate, error)
+	UpdateValidTo(context.Context, *models.FXRate) error
+}
+
+type fxRate struct {
+	*baseRepo[models.FXRate]
+}
+
+func NewFXRateRepo(db bun.IDB) FXRate {
+	return &fxRate{baseRepo: newBaseRepo[models.FXRate](db)}
+}
+
+func (f *fxRate) Create(ctx context.Context, model *models.FXRate) (*models.FXRate, error) {
+	return f.baseRepo.Insert(ctx, model)
+}
+
+// List fetches one page of FX rates matching the query
+func (f *fxRate) List(ctx context.Context, q *ListQuery) ([]*models.FXRate, *Cursor, error) {
+	return f.baseRepo.List(ctx, q)
+}
+
+// GetValidAt fetches the rate converting base into quote whose validity window contains at
+// Returns sql.ErrNoRows when the pair has no rate at that time
+func (f *fxRate) GetValidAt(ctx context.Context, base, quote models.Currency, at time.Time) (*models.FXRate, error) {
+	rate := new(models.FXRate)
+	err := f.conn(ctx).NewSelect().
+		Model(rate).
+		Where("base_currency = ?", base).
+		Where("quote_currency = ?", quote).
+		Where("valid_from <= ?", at).
+		Where("valid_to IS NULL OR valid_to > ?", at).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// GetOpenForUpdate fetches the open ended rate of a currency pair, if any, and locks its row until the surrounding
+// transaction ends so two new rates cannot close it concurrently
+func (f *fxRate) GetOpenForUpdate(ctx context.Context, base, quote models.Currency) (*models.FXRate, error) {
+	rate := new(models.FXRate)
+	err := f.conn(ctx).NewSelect().
+		Model(rate).
+		Where("base_currency = ?", base).
+		Where("quote_currency = ?", quote).
+		Where("valid_to IS NULL").
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// UpdateValidTo persists the end of the validity window of a rate
+func (f *fxRate) UpdateValidTo(ctx context.Context, model *models.FXRate) error {
+	return f.baseRepo.UpdateColumns(ctx, model, "valid_to")
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type IdempotencyKey interface {
+	Reserve(context.Context, *models.IdempotencyKey, time.Duration) (bool, error)
+	GetByKey(context.Context, string) (*models.IdempotencyKey, error)
+	SaveResponse(context.Context, *models.IdempotencyKey) error
+	Release(context.Context, *models.IdempotencyKey) error
+	Purge(context.Context, time.Time) (int64, error)
+	Scrub(context.Context, string) (int64, error)
+}
+
+// scrubbedRequestHash replaces the hash of a scrubbed request, no sha256 of a request is expected to match it
+var scrubbedRequestHash = strings.Repeat("0", 64)
+
+type idempotencyKey struct {
+	*baseRepo[models.IdempotencyKey]
+}
+
+func NewIdempotencyKeyRepo(db bun.IDB) IdempotencyKey {
+	return &idempotencyKey{baseRepo: newBaseRepo[models.IdempotencyKey](db)}
+}
+
+// Reserve claims a key for the request being processed
+// Returns false if the key is already held by another request that has not expired yet,
+// an expired key is taken over in the same statement, and so is a key reserved by the same request more than lease
+// before without a response
+func (i *idempotencyKey) Reserve(ctx context.Context, model *models.IdempotencyKey, lease time.Duration) (bool, error) {
+	res, err := i.conn(ctx).NewInsert().
+		Model(model).
+		On("CONFLICT (key) DO UPDATE").
+		Set("request_hash = EXCLUDED.request_hash").
+		Set("response_code = NULL").
+		Set("response_body = NULL").
+		Set("created_at = EXCLUDED.created_at").
+		Set("reserved_at = EXCLUDED.reserved_at").
+		Set("expires_at = EXCLUDED.expires_at").
+		Where("idempotency_key.expires_at < CURRENT_TIMESTAMP").
+		WhereOr("idempotency_key.response_code IS NULL AND idempotency_key.request_hash = EXCLUDED.request_hash AND idempotency_key.reserved_at < ?",
+			model.ReservedAt.Add(-lease)).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+// GetByKey fetches a stored key
+func (i *idempotencyKey) GetByKey(ctx context.Context, key string) (*models.IdempotencyKey, error) {
+	model := new(models.IdempotencyKey)
+	if err := i.conn(ctx).NewSelect().Model(model).Where("key = ?", key).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// SaveResponse stores the response of the request holding the key, nothing is stored if a retry took the key over
+func (i *idempotencyKey) SaveResponse(ctx context.Context, model *models.IdempotencyKey) error {
+	_, err := i.conn(ctx).NewUpdate().Model(model).Column("response_code", "response_body").WherePK().
+		Where("reserved_at = ?", model.ReservedAt).
+		Exec(ctx)
+	return err
+}
+
+// Release deletes a key so that the request can be retried, unless a retry took the key over
+func (i *idempotencyKey) Release(ctx context.Context, model *models.IdempotencyKey) error {
+	_, err := i.conn(ctx).NewDelete().Model((*models.IdempotencyKey)(nil)).
+		Where("key = ?", model.Key).
+		Where("reserved_at = ?", model.ReservedAt).
+		Exec(ctx)
+	return err
+}
+
+// Purge deletes the keys expired before t along with their stored responses, returns how many were deleted
+func (i *idempotencyKey) Purge(ctx context.Context, t time.Time) (int64, error) {
+	res, err := i.conn(ctx).NewDelete().Model((*models.IdempotencyKey)(nil)).Where("expires_at < ?", t).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Scrub drops the stored responses holding value, along with the hash of their requests which could be matched against
+// guesses of it, returns how many were scrubbed
+// The keys are kept so a retry is rejected as a different request instead of being processed again
+func (i *idempotencyKey) Scrub(ctx context.Context, value string) (int64, error) {
+	res, err := i.conn(ctx).NewUpdate().
+		Model((*models.IdempotencyKey)(nil)).
+		Set("request_hash = ?", scrubbedRequestHash).
+		Set("response_body = NULL").
+		Where("position(? IN response_body) > 0", []byte(value)).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
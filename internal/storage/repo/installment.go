@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type Installment interface {
+	CreateMany(context.Context, []*models.Installment) ([]*models.Installment, error)
+	GetByTransactionID(context.Context, int64) ([]*models.Installment, error)
+}
+
+type installment struct {
+	*baseRepo[models.Installment]
+}
+
+func NewInstallmentRepo(db bun.IDB) Installment {
+	return &installment{baseRepo: newBaseRepo[models.Installment](db)}
+}
+
+// CreateMany inserts the whole installment schedule in a single query
+func (i *installment) CreateMany(ctx context.Context, installments []*models.Installment) ([]*models.Installment, error) {
+	if _, err := i.conn(ctx).NewInsert().Model(&installments).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+// GetByTransactionID fetches the installment schedule of a purchase ordered by installment number
+func (i *installment) GetByTransactionID(ctx context.Context, transactionID int64) ([]*models.Installment, error) {
+	var installments []*models.Installment
+	err := i.conn(ctx).NewSelect().
+		Model(&installments).
+		Where("transaction_id = ?", transactionID).
+		OrderExpr("number ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
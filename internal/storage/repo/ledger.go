@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type Ledger interface {
+	FindOrCreateAccount(context.Context, *models.LedgerAccount) (*models.LedgerAccount, error)
+	CreateEntry(context.Context, *models.JournalEntry) (*models.JournalEntry, error)
+	GetEntryByTransactionID(context.Context, int64) (*models.JournalEntry, error)
+	GetTrialBalance(context.Context) ([]*models.LedgerAccountBalance, error)
+}
+
+type ledger struct {
+	*baseRepo[models.JournalEntry]
+}
+
+func NewLedgerRepo(db bun.IDB) Ledger {
+	return &ledger{baseRepo: newBaseRepo[models.JournalEntry](db)}
+}
+
+// FindOrCreateAccount inserts the ledger account unless one with the same code exists, then returns the stored one
+// DO NOTHING keeps concurrent bookings from locking the shared settlement and fx rows until they commit
+func (l *ledger) FindOrCreateAccount(ctx context.Context, model *models.LedgerAccount) (*models.LedgerAccount, error) {
+	if _, err := l.conn(ctx).NewInsert().Model(model).On("CONFLICT (code) DO NOTHING").Returning("NULL").Exec(ctx); err != nil {
+		return nil, err
+	}
+	account := new(models.LedgerAccount)
+	if err := l.conn(ctx).NewSelect().Model(account).Where("code = ?", model.Code).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// CreateEntry inserts a journal entry along with its postings
+func (l *ledger) CreateEntry(ctx context.Context, model *models.JournalEntry) (*models.JournalEntry, error) {
+	if _, err := l.baseRepo.Insert(ctx, model); err != nil {
+		return nil, err
+	}
+	for _, posting := range model.Postings {
+		posting.JournalEntryID = model.ID
+	}
+	if _, err := l.conn(ctx).NewInsert().Model(&model.Postings).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// GetEntryByTransactionID fetches the journal entry of a transaction with its postings and their ledger accounts
+func (l *ledger) GetEntryByTransactionID(ctx context.Context, transactionID int64) (*models.JournalEntry, error) {
+	entry := new(models.JournalEntry)
+	err := l.conn(ctx).NewSelect().
+		Model(entry).
+		Relation("Postings", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("posting.id ASC")
+		}).
+		Relation("Postings.LedgerAccount").
+		Where("?TableAlias.transaction_id = ?", transactionID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetTrialBalance sums the postings of every ledger account, the debits and credits of a currency always match
+func (l *ledger) GetTrialBalance(ctx context.Context) ([]*models.LedgerAccountBalance, error) {
+	var lines []*models.LedgerAccountBalance
+	err := l.conn(ctx).NewSelect().
+		TableExpr("ledger_accounts AS la").
+		Join("LEFT JOIN postings AS p ON p.ledger_account_id = la.id").
+		ColumnExpr("la.id AS ledger_account_id, la.code, la.kind, la.currency").
+		ColumnExpr("COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0) AS debits").
+		ColumnExpr("COALESCE(-SUM(p.amount) FILTER (WHERE p.amount < 0), 0) AS credits").
+		ColumnExpr("COALESCE(SUM(p.amount), 0) AS balance").
+		GroupExpr("la.id").
+		OrderExpr("la.currency ASC, la.id ASC").
+		Scan(ctx, &lines)
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/akhiltak/pismo-api/internal/storage/repo (interfaces: Account,Transaction,Operation)
+// Source: github.com/akhiltak/pismo-api/internal/storage/repo (interfaces: Account,Transaction,Operation,Installment,IdempotencyKey,Customer,FXRate,Transfer,Ledger,Statement,Accrual,ScheduledTransaction)
 //
 // Generated by this command:
 //
-//	mockgen -destination=internal/storage/repo/mock_repo/mock.go -package=mockRepo github.com/akhiltak/pismo-api/internal/storage/repo Account,Transaction,Operation
+//	mockgen -destination=internal/storage/repo/mock_repo/mock.go -package=mockRepo github.com/akhiltak/pismo-api/internal/storage/repo Account,Transaction,Operation,Installment,IdempotencyKey,Customer,FXRate,Transfer,Ledger,Statement,Accrual,ScheduledTransaction
 //
 
 // Package mockRepo is a generated GoMock package.
@@ -11,9 +11,14 @@ package mockRepo
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/akhiltak/pismo-api/internal/storage/models"
+	repo "github.com/akhiltak/pismo-api/internal/storage/repo"
+	decimal "github.com/shopspring/decimal"
+	bun "github.com/uptrace/bun"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +46,20 @@ func (m *MockAccount) EXPECT() *MockAccountMockRecorder {
 	return m.recorder
 }
 
+// ClearStatusReason mocks base method.
+func (m *MockAccount) ClearStatusReason(arg0 context.Context, arg1 *models.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearStatusReason", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearStatusReason indicates an expected call of ClearStatusReason.
+func (mr *MockAccountMockRecorder) ClearStatusReason(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearStatusReason", reflect.TypeOf((*MockAccount)(nil).ClearStatusReason), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockAccount) Create(arg0 context.Context, arg1 *models.Account) (*models.Account, error) {
 	m.ctrl.T.Helper()
@@ -56,19 +75,35 @@ func (mr *MockAccountMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccount)(nil).Create), arg0, arg1)
 }
 
-// GetAllAccounts mocks base method.
-func (m *MockAccount) GetAllAccounts(arg0 context.Context) ([]*models.Account, error) {
+// GetByCustomerID mocks base method.
+func (m *MockAccount) GetByCustomerID(arg0 context.Context, arg1 int64, arg2 *repo.ListQuery) ([]*models.Account, *repo.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCustomerID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Account)
+	ret1, _ := ret[1].(*repo.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByCustomerID indicates an expected call of GetByCustomerID.
+func (mr *MockAccountMockRecorder) GetByCustomerID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCustomerID", reflect.TypeOf((*MockAccount)(nil).GetByCustomerID), arg0, arg1, arg2)
+}
+
+// GetByCustomerIDForUpdate mocks base method.
+func (m *MockAccount) GetByCustomerIDForUpdate(arg0 context.Context, arg1 int64) ([]*models.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllAccounts", arg0)
+	ret := m.ctrl.Call(m, "GetByCustomerIDForUpdate", arg0, arg1)
 	ret0, _ := ret[0].([]*models.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllAccounts indicates an expected call of GetAllAccounts.
-func (mr *MockAccountMockRecorder) GetAllAccounts(arg0 any) *gomock.Call {
+// GetByCustomerIDForUpdate indicates an expected call of GetByCustomerIDForUpdate.
+func (mr *MockAccountMockRecorder) GetByCustomerIDForUpdate(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAccounts", reflect.TypeOf((*MockAccount)(nil).GetAllAccounts), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCustomerIDForUpdate", reflect.TypeOf((*MockAccount)(nil).GetByCustomerIDForUpdate), arg0, arg1)
 }
 
 // GetByID mocks base method.
@@ -86,6 +121,79 @@ func (mr *MockAccountMockRecorder) GetByID(arg0, arg1, arg2 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAccount)(nil).GetByID), arg0, arg1, arg2)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockAccount) GetByIDForUpdate(arg0 context.Context, arg1 int64) (*models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockAccountMockRecorder) GetByIDForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockAccount)(nil).GetByIDForUpdate), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockAccount) List(arg0 context.Context, arg1 *repo.ListQuery) ([]*models.Account, *repo.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Account)
+	ret1, _ := ret[1].(*repo.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAccountMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAccount)(nil).List), arg0, arg1)
+}
+
+// UpdateCreditLimit mocks base method.
+func (m *MockAccount) UpdateCreditLimit(arg0 context.Context, arg1 *models.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreditLimit", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCreditLimit indicates an expected call of UpdateCreditLimit.
+func (mr *MockAccountMockRecorder) UpdateCreditLimit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreditLimit", reflect.TypeOf((*MockAccount)(nil).UpdateCreditLimit), arg0, arg1)
+}
+
+// UpdateMetadata mocks base method.
+func (m *MockAccount) UpdateMetadata(arg0 context.Context, arg1 *models.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockAccountMockRecorder) UpdateMetadata(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockAccount)(nil).UpdateMetadata), arg0, arg1)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAccount) UpdateStatus(arg0 context.Context, arg1 *models.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAccountMockRecorder) UpdateStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAccount)(nil).UpdateStatus), arg0, arg1)
+}
+
 // MockTransaction is a mock of Transaction interface.
 type MockTransaction struct {
 	ctrl     *gomock.Controller
@@ -110,6 +218,21 @@ func (m *MockTransaction) EXPECT() *MockTransactionMockRecorder {
 	return m.recorder
 }
 
+// ComputeBalance mocks base method.
+func (m *MockTransaction) ComputeBalance(arg0 context.Context, arg1 int64) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComputeBalance", arg0, arg1)
+	ret0, _ := ret[0].(*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComputeBalance indicates an expected call of ComputeBalance.
+func (mr *MockTransactionMockRecorder) ComputeBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeBalance", reflect.TypeOf((*MockTransaction)(nil).ComputeBalance), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockTransaction) Create(arg0 context.Context, arg1 *models.Transaction) (*models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -125,19 +248,257 @@ func (mr *MockTransactionMockRecorder) Create(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransaction)(nil).Create), arg0, arg1)
 }
 
-// GetAllTransactions mocks base method.
-func (m *MockTransaction) GetAllTransactions(arg0 context.Context) ([]*models.Transaction, error) {
+// FreezeForStatement mocks base method.
+func (m *MockTransaction) FreezeForStatement(arg0 context.Context, arg1 *models.Statement) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeForStatement", arg0, arg1)
+	ret0, _ := ret[0].(*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeForStatement indicates an expected call of FreezeForStatement.
+func (mr *MockTransactionMockRecorder) FreezeForStatement(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeForStatement", reflect.TypeOf((*MockTransaction)(nil).FreezeForStatement), arg0, arg1)
+}
+
+// GetBalance mocks base method.
+func (m *MockTransaction) GetBalance(arg0 context.Context, arg1 int64) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", arg0, arg1)
+	ret0, _ := ret[0].(*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockTransactionMockRecorder) GetBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockTransaction)(nil).GetBalance), arg0, arg1)
+}
+
+// GetBalanceDrifts mocks base method.
+func (m *MockTransaction) GetBalanceDrifts(arg0 context.Context) ([]*models.BalanceDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceDrifts", arg0)
+	ret0, _ := ret[0].([]*models.BalanceDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceDrifts indicates an expected call of GetBalanceDrifts.
+func (mr *MockTransactionMockRecorder) GetBalanceDrifts(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceDrifts", reflect.TypeOf((*MockTransaction)(nil).GetBalanceDrifts), arg0)
+}
+
+// GetByAccountID mocks base method.
+func (m *MockTransaction) GetByAccountID(arg0 context.Context, arg1 int64, arg2 *repo.ListQuery) ([]*models.Transaction, *repo.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAccountID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Transaction)
+	ret1, _ := ret[1].(*repo.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByAccountID indicates an expected call of GetByAccountID.
+func (mr *MockTransactionMockRecorder) GetByAccountID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccountID", reflect.TypeOf((*MockTransaction)(nil).GetByAccountID), arg0, arg1, arg2)
+}
+
+// GetByID mocks base method.
+func (m *MockTransaction) GetByID(arg0 context.Context, arg1 int64, arg2 bool) (*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTransactionMockRecorder) GetByID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTransaction)(nil).GetByID), arg0, arg1, arg2)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockTransaction) GetByIDForUpdate(arg0 context.Context, arg1 int64) (*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockTransactionMockRecorder) GetByIDForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockTransaction)(nil).GetByIDForUpdate), arg0, arg1)
+}
+
+// GetOpenDebits mocks base method.
+func (m *MockTransaction) GetOpenDebits(arg0 context.Context, arg1 int64) ([]*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenDebits", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenDebits indicates an expected call of GetOpenDebits.
+func (mr *MockTransactionMockRecorder) GetOpenDebits(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenDebits", reflect.TypeOf((*MockTransaction)(nil).GetOpenDebits), arg0, arg1)
+}
+
+// GetOverdueDebits mocks base method.
+func (m *MockTransaction) GetOverdueDebits(arg0 context.Context, arg1 int64, arg2 time.Time) ([]*models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueDebits", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueDebits indicates an expected call of GetOverdueDebits.
+func (mr *MockTransactionMockRecorder) GetOverdueDebits(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueDebits", reflect.TypeOf((*MockTransaction)(nil).GetOverdueDebits), arg0, arg1, arg2)
+}
+
+// GetPaidAmount mocks base method.
+func (m *MockTransaction) GetPaidAmount(arg0 context.Context, arg1 int64, arg2, arg3 time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaidAmount", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaidAmount indicates an expected call of GetPaidAmount.
+func (mr *MockTransactionMockRecorder) GetPaidAmount(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaidAmount", reflect.TypeOf((*MockTransaction)(nil).GetPaidAmount), arg0, arg1, arg2, arg3)
+}
+
+// GetRefundedAmount mocks base method.
+func (m *MockTransaction) GetRefundedAmount(arg0 context.Context, arg1 int64) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefundedAmount", arg0, arg1)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefundedAmount indicates an expected call of GetRefundedAmount.
+func (mr *MockTransactionMockRecorder) GetRefundedAmount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefundedAmount", reflect.TypeOf((*MockTransaction)(nil).GetRefundedAmount), arg0, arg1)
+}
+
+// HasPending mocks base method.
+func (m *MockTransaction) HasPending(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPending", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPending indicates an expected call of HasPending.
+func (mr *MockTransactionMockRecorder) HasPending(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPending", reflect.TypeOf((*MockTransaction)(nil).HasPending), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockTransaction) List(arg0 context.Context, arg1 *repo.ListQuery) ([]*models.Transaction, *repo.Cursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTransactions", arg0)
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
 	ret0, _ := ret[0].([]*models.Transaction)
+	ret1, _ := ret[1].(*repo.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockTransactionMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTransaction)(nil).List), arg0, arg1)
+}
+
+// RebuildBalance mocks base method.
+func (m *MockTransaction) RebuildBalance(arg0 context.Context, arg1 int64) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildBalance", arg0, arg1)
+	ret0, _ := ret[0].(*models.Balance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTransactions indicates an expected call of GetAllTransactions.
-func (mr *MockTransactionMockRecorder) GetAllTransactions(arg0 any) *gomock.Call {
+// RebuildBalance indicates an expected call of RebuildBalance.
+func (mr *MockTransactionMockRecorder) RebuildBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildBalance", reflect.TypeOf((*MockTransaction)(nil).RebuildBalance), arg0, arg1)
+}
+
+// RunInTx mocks base method.
+func (m *MockTransaction) RunInTx(arg0 context.Context, arg1 *sql.TxOptions, arg2 func(context.Context, bun.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MockTransactionMockRecorder) RunInTx(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*MockTransaction)(nil).RunInTx), arg0, arg1, arg2)
+}
+
+// Settle mocks base method.
+func (m *MockTransaction) Settle(arg0 context.Context, arg1 *models.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Settle", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Settle indicates an expected call of Settle.
+func (mr *MockTransactionMockRecorder) Settle(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Settle", reflect.TypeOf((*MockTransaction)(nil).Settle), arg0, arg1)
+}
+
+// UpdateBalance mocks base method.
+func (m *MockTransaction) UpdateBalance(arg0 context.Context, arg1 *models.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBalance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBalance indicates an expected call of UpdateBalance.
+func (mr *MockTransactionMockRecorder) UpdateBalance(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockTransaction)(nil).UpdateBalance), arg0, arg1)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockTransaction) UpdateStatus(arg0 context.Context, arg1 *models.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockTransactionMockRecorder) UpdateStatus(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTransactions", reflect.TypeOf((*MockTransaction)(nil).GetAllTransactions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTransaction)(nil).UpdateStatus), arg0, arg1)
 }
 
 // MockOperation is a mock of Operation interface.
@@ -164,6 +525,50 @@ func (m *MockOperation) EXPECT() *MockOperationMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockOperation) Create(arg0 context.Context, arg1 *models.OperationType) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOperationMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOperation)(nil).Create), arg0, arg1)
+}
+
+// Deactivate mocks base method.
+func (m *MockOperation) Deactivate(arg0 context.Context, arg1 *models.OperationType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockOperationMockRecorder) Deactivate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockOperation)(nil).Deactivate), arg0, arg1)
+}
+
+// GetByCode mocks base method.
+func (m *MockOperation) GetByCode(arg0 context.Context, arg1 string) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", arg0, arg1)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockOperationMockRecorder) GetByCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockOperation)(nil).GetByCode), arg0, arg1)
+}
+
 // GetByID mocks base method.
 func (m *MockOperation) GetByID(arg0 context.Context, arg1 int64, arg2 bool) (*models.OperationType, error) {
 	m.ctrl.T.Helper()
@@ -178,3 +583,859 @@ func (mr *MockOperationMockRecorder) GetByID(arg0, arg1, arg2 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOperation)(nil).GetByID), arg0, arg1, arg2)
 }
+
+// GetByIDForShare mocks base method.
+func (m *MockOperation) GetByIDForShare(arg0 context.Context, arg1 int64) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForShare", arg0, arg1)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForShare indicates an expected call of GetByIDForShare.
+func (mr *MockOperationMockRecorder) GetByIDForShare(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForShare", reflect.TypeOf((*MockOperation)(nil).GetByIDForShare), arg0, arg1)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockOperation) GetByIDForUpdate(arg0 context.Context, arg1 int64) (*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockOperationMockRecorder) GetByIDForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockOperation)(nil).GetByIDForUpdate), arg0, arg1)
+}
+
+// HasTransactions mocks base method.
+func (m *MockOperation) HasTransactions(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasTransactions", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasTransactions indicates an expected call of HasTransactions.
+func (mr *MockOperationMockRecorder) HasTransactions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTransactions", reflect.TypeOf((*MockOperation)(nil).HasTransactions), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockOperation) List(arg0 context.Context, arg1 *bool) ([]*models.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*models.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockOperationMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOperation)(nil).List), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockOperation) Update(arg0 context.Context, arg1 *models.OperationType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOperationMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOperation)(nil).Update), arg0, arg1)
+}
+
+// MockInstallment is a mock of Installment interface.
+type MockInstallment struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallmentMockRecorder
+	isgomock struct{}
+}
+
+// MockInstallmentMockRecorder is the mock recorder for MockInstallment.
+type MockInstallmentMockRecorder struct {
+	mock *MockInstallment
+}
+
+// NewMockInstallment creates a new mock instance.
+func NewMockInstallment(ctrl *gomock.Controller) *MockInstallment {
+	mock := &MockInstallment{ctrl: ctrl}
+	mock.recorder = &MockInstallmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallment) EXPECT() *MockInstallmentMockRecorder {
+	return m.recorder
+}
+
+// CreateMany mocks base method.
+func (m *MockInstallment) CreateMany(arg0 context.Context, arg1 []*models.Installment) ([]*models.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockInstallmentMockRecorder) CreateMany(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockInstallment)(nil).CreateMany), arg0, arg1)
+}
+
+// GetByTransactionID mocks base method.
+func (m *MockInstallment) GetByTransactionID(arg0 context.Context, arg1 int64) ([]*models.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTransactionID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTransactionID indicates an expected call of GetByTransactionID.
+func (mr *MockInstallmentMockRecorder) GetByTransactionID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTransactionID", reflect.TypeOf((*MockInstallment)(nil).GetByTransactionID), arg0, arg1)
+}
+
+// MockIdempotencyKey is a mock of IdempotencyKey interface.
+type MockIdempotencyKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyKeyMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyKeyMockRecorder is the mock recorder for MockIdempotencyKey.
+type MockIdempotencyKeyMockRecorder struct {
+	mock *MockIdempotencyKey
+}
+
+// NewMockIdempotencyKey creates a new mock instance.
+func NewMockIdempotencyKey(ctrl *gomock.Controller) *MockIdempotencyKey {
+	mock := &MockIdempotencyKey{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyKey) EXPECT() *MockIdempotencyKeyMockRecorder {
+	return m.recorder
+}
+
+// GetByKey mocks base method.
+func (m *MockIdempotencyKey) GetByKey(arg0 context.Context, arg1 string) (*models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", arg0, arg1)
+	ret0, _ := ret[0].(*models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockIdempotencyKeyMockRecorder) GetByKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockIdempotencyKey)(nil).GetByKey), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockIdempotencyKey) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIdempotencyKeyMockRecorder) Purge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIdempotencyKey)(nil).Purge), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockIdempotencyKey) Release(arg0 context.Context, arg1 *models.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyKeyMockRecorder) Release(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyKey)(nil).Release), arg0, arg1)
+}
+
+// Reserve mocks base method.
+func (m *MockIdempotencyKey) Reserve(arg0 context.Context, arg1 *models.IdempotencyKey, arg2 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIdempotencyKeyMockRecorder) Reserve(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotencyKey)(nil).Reserve), arg0, arg1, arg2)
+}
+
+// SaveResponse mocks base method.
+func (m *MockIdempotencyKey) SaveResponse(arg0 context.Context, arg1 *models.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResponse", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockIdempotencyKeyMockRecorder) SaveResponse(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*MockIdempotencyKey)(nil).SaveResponse), arg0, arg1)
+}
+
+// Scrub mocks base method.
+func (m *MockIdempotencyKey) Scrub(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scrub", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scrub indicates an expected call of Scrub.
+func (mr *MockIdempotencyKeyMockRecorder) Scrub(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scrub", reflect.TypeOf((*MockIdempotencyKey)(nil).Scrub), arg0, arg1)
+}
+
+// MockCustomer is a mock of Customer interface.
+type MockCustomer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomerMockRecorder is the mock recorder for MockCustomer.
+type MockCustomerMockRecorder struct {
+	mock *MockCustomer
+}
+
+// NewMockCustomer creates a new mock instance.
+func NewMockCustomer(ctrl *gomock.Controller) *MockCustomer {
+	mock := &MockCustomer{ctrl: ctrl}
+	mock.recorder = &MockCustomerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomer) EXPECT() *MockCustomerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomer) Create(arg0 context.Context, arg1 *models.Customer) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomerMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomer)(nil).Create), arg0, arg1)
+}
+
+// Erase mocks base method.
+func (m *MockCustomer) Erase(arg0 context.Context, arg1 *models.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Erase indicates an expected call of Erase.
+func (mr *MockCustomerMockRecorder) Erase(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erase", reflect.TypeOf((*MockCustomer)(nil).Erase), arg0, arg1)
+}
+
+// FindOrCreateByDocument mocks base method.
+func (m *MockCustomer) FindOrCreateByDocument(arg0 context.Context, arg1 *models.Customer) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateByDocument", arg0, arg1)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateByDocument indicates an expected call of FindOrCreateByDocument.
+func (mr *MockCustomerMockRecorder) FindOrCreateByDocument(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByDocument", reflect.TypeOf((*MockCustomer)(nil).FindOrCreateByDocument), arg0, arg1)
+}
+
+// GetByID mocks base method.
+func (m *MockCustomer) GetByID(arg0 context.Context, arg1 int64) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCustomerMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCustomer)(nil).GetByID), arg0, arg1)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockCustomer) GetByIDForUpdate(arg0 context.Context, arg1 int64) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockCustomerMockRecorder) GetByIDForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockCustomer)(nil).GetByIDForUpdate), arg0, arg1)
+}
+
+// MockFXRate is a mock of FXRate interface.
+type MockFXRate struct {
+	ctrl     *gomock.Controller
+	recorder *MockFXRateMockRecorder
+	isgomock struct{}
+}
+
+// MockFXRateMockRecorder is the mock recorder for MockFXRate.
+type MockFXRateMockRecorder struct {
+	mock *MockFXRate
+}
+
+// NewMockFXRate creates a new mock instance.
+func NewMockFXRate(ctrl *gomock.Controller) *MockFXRate {
+	mock := &MockFXRate{ctrl: ctrl}
+	mock.recorder = &MockFXRateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFXRate) EXPECT() *MockFXRateMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFXRate) Create(arg0 context.Context, arg1 *models.FXRate) (*models.FXRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.FXRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFXRateMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFXRate)(nil).Create), arg0, arg1)
+}
+
+// GetOpenForUpdate mocks base method.
+func (m *MockFXRate) GetOpenForUpdate(arg0 context.Context, arg1, arg2 models.Currency) (*models.FXRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenForUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.FXRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenForUpdate indicates an expected call of GetOpenForUpdate.
+func (mr *MockFXRateMockRecorder) GetOpenForUpdate(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenForUpdate", reflect.TypeOf((*MockFXRate)(nil).GetOpenForUpdate), arg0, arg1, arg2)
+}
+
+// GetValidAt mocks base method.
+func (m *MockFXRate) GetValidAt(arg0 context.Context, arg1, arg2 models.Currency, arg3 time.Time) (*models.FXRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidAt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.FXRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidAt indicates an expected call of GetValidAt.
+func (mr *MockFXRateMockRecorder) GetValidAt(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidAt", reflect.TypeOf((*MockFXRate)(nil).GetValidAt), arg0, arg1, arg2, arg3)
+}
+
+// List mocks base method.
+func (m *MockFXRate) List(arg0 context.Context, arg1 *repo.ListQuery) ([]*models.FXRate, *repo.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*models.FXRate)
+	ret1, _ := ret[1].(*repo.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockFXRateMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFXRate)(nil).List), arg0, arg1)
+}
+
+// UpdateValidTo mocks base method.
+func (m *MockFXRate) UpdateValidTo(arg0 context.Context, arg1 *models.FXRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateValidTo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateValidTo indicates an expected call of UpdateValidTo.
+func (mr *MockFXRateMockRecorder) UpdateValidTo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValidTo", reflect.TypeOf((*MockFXRate)(nil).UpdateValidTo), arg0, arg1)
+}
+
+// MockTransfer is a mock of Transfer interface.
+type MockTransfer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferMockRecorder
+	isgomock struct{}
+}
+
+// MockTransferMockRecorder is the mock recorder for MockTransfer.
+type MockTransferMockRecorder struct {
+	mock *MockTransfer
+}
+
+// NewMockTransfer creates a new mock instance.
+func NewMockTransfer(ctrl *gomock.Controller) *MockTransfer {
+	mock := &MockTransfer{ctrl: ctrl}
+	mock.recorder = &MockTransferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransfer) EXPECT() *MockTransferMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTransfer) Create(arg0 context.Context, arg1 *models.Transfer) (*models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTransferMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransfer)(nil).Create), arg0, arg1)
+}
+
+// GetByID mocks base method.
+func (m *MockTransfer) GetByID(arg0 context.Context, arg1 int64) (*models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTransferMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTransfer)(nil).GetByID), arg0, arg1)
+}
+
+// MockLedger is a mock of Ledger interface.
+type MockLedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerMockRecorder is the mock recorder for MockLedger.
+type MockLedgerMockRecorder struct {
+	mock *MockLedger
+}
+
+// NewMockLedger creates a new mock instance.
+func NewMockLedger(ctrl *gomock.Controller) *MockLedger {
+	mock := &MockLedger{ctrl: ctrl}
+	mock.recorder = &MockLedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedger) EXPECT() *MockLedgerMockRecorder {
+	return m.recorder
+}
+
+// CreateEntry mocks base method.
+func (m *MockLedger) CreateEntry(arg0 context.Context, arg1 *models.JournalEntry) (*models.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", arg0, arg1)
+	ret0, _ := ret[0].(*models.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockLedgerMockRecorder) CreateEntry(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockLedger)(nil).CreateEntry), arg0, arg1)
+}
+
+// FindOrCreateAccount mocks base method.
+func (m *MockLedger) FindOrCreateAccount(arg0 context.Context, arg1 *models.LedgerAccount) (*models.LedgerAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateAccount", arg0, arg1)
+	ret0, _ := ret[0].(*models.LedgerAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateAccount indicates an expected call of FindOrCreateAccount.
+func (mr *MockLedgerMockRecorder) FindOrCreateAccount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateAccount", reflect.TypeOf((*MockLedger)(nil).FindOrCreateAccount), arg0, arg1)
+}
+
+// GetEntryByTransactionID mocks base method.
+func (m *MockLedger) GetEntryByTransactionID(arg0 context.Context, arg1 int64) (*models.JournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntryByTransactionID", arg0, arg1)
+	ret0, _ := ret[0].(*models.JournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntryByTransactionID indicates an expected call of GetEntryByTransactionID.
+func (mr *MockLedgerMockRecorder) GetEntryByTransactionID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntryByTransactionID", reflect.TypeOf((*MockLedger)(nil).GetEntryByTransactionID), arg0, arg1)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedger) GetTrialBalance(arg0 context.Context) ([]*models.LedgerAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", arg0)
+	ret0, _ := ret[0].([]*models.LedgerAccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerMockRecorder) GetTrialBalance(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedger)(nil).GetTrialBalance), arg0)
+}
+
+// MockStatement is a mock of Statement interface.
+type MockStatement struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementMockRecorder is the mock recorder for MockStatement.
+type MockStatementMockRecorder struct {
+	mock *MockStatement
+}
+
+// NewMockStatement creates a new mock instance.
+func NewMockStatement(ctrl *gomock.Controller) *MockStatement {
+	mock := &MockStatement{ctrl: ctrl}
+	mock.recorder = &MockStatementMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatement) EXPECT() *MockStatementMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatement) Create(arg0 context.Context, arg1 *models.Statement) (*models.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatementMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatement)(nil).Create), arg0, arg1)
+}
+
+// GetByAccountID mocks base method.
+func (m *MockStatement) GetByAccountID(arg0 context.Context, arg1 int64, arg2 *repo.ListQuery) ([]*models.Statement, *repo.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAccountID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Statement)
+	ret1, _ := ret[1].(*repo.Cursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByAccountID indicates an expected call of GetByAccountID.
+func (mr *MockStatementMockRecorder) GetByAccountID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccountID", reflect.TypeOf((*MockStatement)(nil).GetByAccountID), arg0, arg1, arg2)
+}
+
+// GetByID mocks base method.
+func (m *MockStatement) GetByID(arg0 context.Context, arg1 int64) (*models.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockStatementMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStatement)(nil).GetByID), arg0, arg1)
+}
+
+// GetDueOn mocks base method.
+func (m *MockStatement) GetDueOn(arg0 context.Context, arg1 int64, arg2 time.Time) (*models.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueOn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueOn indicates an expected call of GetDueOn.
+func (mr *MockStatementMockRecorder) GetDueOn(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueOn", reflect.TypeOf((*MockStatement)(nil).GetDueOn), arg0, arg1, arg2)
+}
+
+// GetLatest mocks base method.
+func (m *MockStatement) GetLatest(arg0 context.Context, arg1 int64) (*models.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", arg0, arg1)
+	ret0, _ := ret[0].(*models.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockStatementMockRecorder) GetLatest(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockStatement)(nil).GetLatest), arg0, arg1)
+}
+
+// UpdateTotals mocks base method.
+func (m *MockStatement) UpdateTotals(arg0 context.Context, arg1 *models.Statement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTotals", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTotals indicates an expected call of UpdateTotals.
+func (mr *MockStatementMockRecorder) UpdateTotals(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTotals", reflect.TypeOf((*MockStatement)(nil).UpdateTotals), arg0, arg1)
+}
+
+// MockAccrual is a mock of Accrual interface.
+type MockAccrual struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccrualMockRecorder
+	isgomock struct{}
+}
+
+// MockAccrualMockRecorder is the mock recorder for MockAccrual.
+type MockAccrualMockRecorder struct {
+	mock *MockAccrual
+}
+
+// NewMockAccrual creates a new mock instance.
+func NewMockAccrual(ctrl *gomock.Controller) *MockAccrual {
+	mock := &MockAccrual{ctrl: ctrl}
+	mock.recorder = &MockAccrualMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccrual) EXPECT() *MockAccrualMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAccrual) Create(arg0 context.Context, arg1 *models.Accrual) (*models.Accrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.Accrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccrualMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccrual)(nil).Create), arg0, arg1)
+}
+
+// Exists mocks base method.
+func (m *MockAccrual) Exists(arg0 context.Context, arg1 int64, arg2 models.AccrualKind, arg3 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockAccrualMockRecorder) Exists(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockAccrual)(nil).Exists), arg0, arg1, arg2, arg3)
+}
+
+// MockScheduledTransaction is a mock of ScheduledTransaction interface.
+type MockScheduledTransaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledTransactionMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduledTransactionMockRecorder is the mock recorder for MockScheduledTransaction.
+type MockScheduledTransactionMockRecorder struct {
+	mock *MockScheduledTransaction
+}
+
+// NewMockScheduledTransaction creates a new mock instance.
+func NewMockScheduledTransaction(ctrl *gomock.Controller) *MockScheduledTransaction {
+	mock := &MockScheduledTransaction{ctrl: ctrl}
+	mock.recorder = &MockScheduledTransactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledTransaction) EXPECT() *MockScheduledTransactionMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockScheduledTransaction) Create(arg0 context.Context, arg1 *models.ScheduledTransaction) (*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockScheduledTransactionMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockScheduledTransaction)(nil).Create), arg0, arg1)
+}
+
+// CreateExecution mocks base method.
+func (m *MockScheduledTransaction) CreateExecution(arg0 context.Context, arg1 *models.ScheduledExecution) (*models.ScheduledExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExecution", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExecution indicates an expected call of CreateExecution.
+func (mr *MockScheduledTransactionMockRecorder) CreateExecution(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExecution", reflect.TypeOf((*MockScheduledTransaction)(nil).CreateExecution), arg0, arg1)
+}
+
+// GetByID mocks base method.
+func (m *MockScheduledTransaction) GetByID(arg0 context.Context, arg1 int64) (*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockScheduledTransactionMockRecorder) GetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockScheduledTransaction)(nil).GetByID), arg0, arg1)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockScheduledTransaction) GetByIDForUpdate(arg0 context.Context, arg1 int64) (*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockScheduledTransactionMockRecorder) GetByIDForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockScheduledTransaction)(nil).GetByIDForUpdate), arg0, arg1)
+}
+
+// GetDue mocks base method.
+func (m *MockScheduledTransaction) GetDue(arg0 context.Context, arg1 time.Time, arg2 int) ([]*models.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockScheduledTransactionMockRecorder) GetDue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockScheduledTransaction)(nil).GetDue), arg0, arg1, arg2)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockScheduledTransaction) UpdateSchedule(arg0 context.Context, arg1 *models.ScheduledTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockScheduledTransactionMockRecorder) UpdateSchedule(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockScheduledTransaction)(nil).UpdateSchedule), arg0, arg1)
+}
@@ -8,7 +8,15 @@ import (
 )
 
 type Operation interface {
+	Create(context.Context, *models.OperationType) (*models.OperationType, error)
+	List(context.Context, *bool) ([]*models.OperationType, error)
 	GetByID(context.Context, int64, bool) (*models.OperationType, error)
+	GetByIDForUpdate(context.Context, int64) (*models.OperationType, error)
+	GetByIDForShare(context.Context, int64) (*models.OperationType, error)
+	GetByCode(context.Context, string) (*models.OperationType, error)
+	Update(context.Context, *models.OperationType) error
+	Deactivate(context.Context, *models.OperationType) error
+	HasTransactions(context.Context, int64) (bool, error)
 }
 
 type operation struct {
@@ -19,7 +27,64 @@ func NewOperationRepo(db bun.IDB) Operation {
 	return &operation{baseRepo: newBaseRepo[models.OperationType](db)}
 }
 
+func (o *operation) Create(ctx context.Context, model *models.OperationType) (*models.OperationType, error) {
+	return o.baseRepo.Insert(ctx, model)
+}
+
+// List fetches all Operation types ordered by ID, optionally only the active or inactive ones
+func (o *operation) List(ctx context.Context, active *bool) ([]*models.OperationType, error) {
+	var operations []*models.OperationType
+	query := o.conn(ctx).NewSelect().Model(&operations)
+	if active != nil {
+		query = query.Where("active = ?", *active)
+	}
+	if err := query.OrderExpr("id ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
 // GetByID fetches an Operation by ID
 func (o *operation) GetByID(ctx context.Context, id int64, associations bool) (*models.OperationType, error) {
 	return o.baseRepo.FindByID(ctx, id, "")
 }
+
+// GetByIDForUpdate fetches an Operation type by ID and locks its row until the surrounding transaction ends
+func (o *operation) GetByIDForUpdate(ctx context.Context, id int64) (*models.OperationType, error) {
+	return o.baseRepo.LockByID(ctx, id)
+}
+
+// GetByIDForShare fetches an Operation type by ID and keeps its row from being updated until the surrounding
+// transaction ends, other transactions can still share it
+func (o *operation) GetByIDForShare(ctx context.Context, id int64) (*models.OperationType, error) {
+	operation := new(models.OperationType)
+	if err := o.conn(ctx).NewSelect().Model(operation).Where("id = ?", id).For("SHARE").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// GetByCode fetches an Operation type reserved to the service by its code
+func (o *operation) GetByCode(ctx context.Context, code string) (*models.OperationType, error) {
+	operation := new(models.OperationType)
+	if err := o.conn(ctx).NewSelect().Model(operation).Where("code = ?", code).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// Update persists the description, entry type, installments flag and interest rate of an Operation type
+func (o *operation) Update(ctx context.Context, model *models.OperationType) error {
+	return o.baseRepo.UpdateColumns(ctx, model, "description", "entry_type", "installments", "interest_rate")
+}
+
+// Deactivate marks an Operation type as inactive, it is kept for the transactions already booked with it
+func (o *operation) Deactivate(ctx context.Context, model *models.OperationType) error {
+	model.Active = false
+	return o.baseRepo.UpdateColumns(ctx, model, "active")
+}
+
+// HasTransactions tells whether any transaction was booked with an Operation type
+func (o *operation) HasTransactions(ctx context.Context, id int64) (bool, error) {
+	return o.conn(ctx).NewSelect().Model((*models.Transaction)(nil)).Where("operation_type_id = ?", id).Exists(ctx)
+}
@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at the last row of a page, it is handed out to clients as an opaque token.
+// Value is the sort column of that row and ID breaks ties between rows with the same value.
+// Sort is the sort of the page (see sortKey), Value only means something for that sort.
+type Cursor struct {
+	Sort  string `json:"s,omitempty"`
+	Value string `json:"v,omitempty"`
+	ID    int64  `json:"id"`
+}
+
+// Encode returns the opaque token of the cursor
+func (c *Cursor) Encode() string {
+	if c == nil {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token returned by Encode for the given sort (a column, prefixed with - for descending,
+// empty for the default sort by id), an empty token means the first page
+// A token handed out for another sort is rejected, its value could not be compared with the sort column
+func DecodeCursor(token, sort string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := &Cursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != sortKey(cmp.Or(strings.TrimPrefix(sort, "-"), "id"), strings.HasPrefix(sort, "-")) {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// sortKey identifies a sort in a cursor, empty for the default sort by id ascending
+func sortKey(column string, desc bool) string {
+	switch {
+	case desc:
+		return "-" + column
+	case column == "id":
+		return ""
+	default:
+		return column
+	}
+}
+
+// ListQuery is a reusable builder for filtered and keyset (cursor) paginated list queries.
+// Rows are sorted by a column and then by id, so pages stay stable while new rows are inserted.
+type ListQuery struct {
+	filters   []func(*bun.SelectQuery) *bun.SelectQuery
+	relations []string
+	sortBy    string
+	desc      bool
+	after     *Cursor
+	limit     int
+}
+
+func NewListQuery() *ListQuery {
+	return &ListQuery{sortBy: "id", limit: DefaultPageLimit}
+}
+
+// Where adds a raw condition, ?TableAlias can be used to qualify columns of the listed table
+func (q *ListQuery) Where(query string, args ...any) *ListQuery {
+	q.filters = append(q.filters, func(sq *bun.SelectQuery) *bun.SelectQuery {
+		return sq.Where(query, args...)
+	})
+	return q
+}
+
+// Eq filters rows where column = value
+func (q *ListQuery) Eq(column string, value any) *ListQuery {
+	return q.Where("?TableAlias.? = ?", bun.Ident(column), value)
+}
+
+// Gte filters rows where column >= value
+func (q *ListQuery) Gte(column string, value any) *ListQuery {
+	return q.Where("?TableAlias.? >= ?", bun.Ident(column), value)
+}
+
+// Lte filters rows where column <= value
+func (q *ListQuery) Lte(column string, value any) *ListQuery {
+	return q.Where("?TableAlias.? <= ?", bun.Ident(column), value)
+}
+
+// Contains filters rows whose JSONB column contains value (@>), value is marshalled to JSON
+func (q *ListQuery) Contains(column string, value any) *ListQuery {
+	data, _ := json.Marshal(value)
+	return q.Where("?TableAlias.? @> ?::jsonb", bun.Ident(column), string(data))
+}
+
+// Relation loads a bun relation along with each row
+func (q *ListQuery) Relation(name string) *ListQuery {
+	q.relations = append(q.relations, name)
+	return q
+}
+
+// OrderBy sorts the rows by column (and id), ascending unless desc is set
+func (q *ListQuery) OrderBy(column string, desc bool) *ListQuery {
+	q.sortBy, q.desc = column, desc
+	return q
+}
+
+// Page sets the cursor to start after and the page size, the limit is capped at MaxPageLimit
+func (q *ListQuery) Page(after *Cursor, limit int) *ListQuery {
+	q.after = after
+	q.limit = DefaultPageLimit
+	if limit > 0 {
+		q.limit = min(limit, MaxPageLimit)
+	}
+	return q
+}
+
+// apply builds the select query for one page, one extra row is fetched to know if there is a next page
+func (q *ListQuery) apply(sq *bun.SelectQuery) *bun.SelectQuery {
+	for _, filter := range q.filters {
+		sq = filter(sq)
+	}
+	for _, relation := range q.relations {
+		sq = sq.Relation(relation)
+	}
+
+	direction, comparison := "ASC", ">"
+	if q.desc {
+		direction, comparison = "DESC", "<"
+	}
+	if q.after != nil {
+		if q.sortBy == "id" {
+			sq = sq.Where("?TableAlias.id "+comparison+" ?", q.after.ID)
+		} else {
+			sq = sq.Where("(?TableAlias.?, ?TableAlias.id) "+comparison+" (?, ?)", bun.Ident(q.sortBy), q.after.Value, q.after.ID)
+		}
+	}
+	if q.sortBy != "id" {
+		sq = sq.OrderExpr("?TableAlias.? "+direction, bun.Ident(q.sortBy))
+	}
+	return sq.OrderExpr("?TableAlias.id " + direction).Limit(q.limit + 1)
+}
+
+// nextCursor trims the extra row fetched by apply and returns the cursor of the next page (nil on the last page)
+func nextCursor[T any](db bun.IDB, q *ListQuery, rows []*T) ([]*T, *Cursor) {
+	if len(rows) <= q.limit {
+		return rows, nil
+	}
+	rows = rows[:q.limit]
+	last := reflect.ValueOf(rows[len(rows)-1]).Elem()
+	table := db.Dialect().Tables().Get(last.Type())
+
+	cursor := &Cursor{Sort: sortKey(q.sortBy, q.desc), ID: table.FieldMap["id"].Value(last).Int()}
+	if q.sortBy != "id" {
+		switch v := table.FieldMap[q.sortBy].Value(last).Interface().(type) {
+		case time.Time:
+			cursor.Value = v.Format(time.RFC3339Nano)
+		default:
+			cursor.Value = fmt.Sprint(v)
+		}
+	}
+	return rows, cursor
+}
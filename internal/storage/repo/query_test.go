@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// testDB renders queries without connecting to a database
+func testDB() *bun.DB {
+	return bun.NewDB(sql.OpenDB(pgdriver.NewConnector()), pgdialect.New())
+}
+
+func TestCursor(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		cursor := &Cursor{Sort: "-event_date", Value: "2025-02-01T00:00:00Z", ID: 42}
+		decoded, err := DecodeCursor(cursor.Encode(), "-event_date")
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+
+		cursor = &Cursor{ID: 42}
+		for _, sort := range []string{"", "id"} {
+			decoded, err = DecodeCursor(cursor.Encode(), sort)
+			assert.NoError(t, err)
+			assert.Equal(t, cursor, decoded)
+		}
+	})
+
+	t.Run("token of another sort", func(t *testing.T) {
+		for _, sort := range []string{"", "event_date", "-amount"} {
+			_, err := DecodeCursor((&Cursor{Sort: "-event_date", Value: "2025-02-01T00:00:00Z", ID: 42}).Encode(), sort)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		}
+		_, err := DecodeCursor((&Cursor{ID: 42}).Encode(), "-id")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("empty token is the first page", func(t *testing.T) {
+		decoded, err := DecodeCursor("", "")
+		assert.NoError(t, err)
+		assert.Nil(t, decoded)
+		assert.Equal(t, "", (*Cursor)(nil).Encode())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := DecodeCursor("not a cursor", "")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestListQuery(t *testing.T) {
+	db := testDB()
+
+	t.Run("filters and default sort", func(t *testing.T) {
+		var rows []*models.Transaction
+		q := NewListQuery().Eq("account_id", 42).Lte("amount", 10).Page(&Cursor{ID: 7}, 0)
+
+		query := q.apply(db.NewSelect().Model(&rows)).String()
+		assert.Contains(t, query, `WHERE ("transaction"."account_id" = 42) AND ("transaction"."amount" <= 10) AND ("transaction".id > 7)`)
+		assert.Contains(t, query, `ORDER BY "transaction".id ASC LIMIT 51`)
+	})
+
+	t.Run("jsonb containment", func(t *testing.T) {
+		var rows []*models.Account
+		q := NewListQuery().Contains("metadata", map[string]string{"crm_id": "o'brien"})
+
+		query := q.apply(db.NewSelect().Model(&rows)).String()
+		assert.Contains(t, query, `WHERE ("account"."metadata" @> '{"crm_id":"o''brien"}'::jsonb)`)
+	})
+
+	t.Run("keyset on sort column", func(t *testing.T) {
+		var rows []*models.Transaction
+		q := NewListQuery().OrderBy("event_date", true).Page(&Cursor{Value: "2025-02-01T00:00:00Z", ID: 7}, 500)
+
+		query := q.apply(db.NewSelect().Model(&rows)).String()
+		assert.Contains(t, query, `WHERE (("transaction"."event_date", "transaction".id) < ('2025-02-01T00:00:00Z', 7))`)
+		assert.Contains(t, query, `ORDER BY "transaction"."event_date" DESC, "transaction".id DESC LIMIT 101`)
+	})
+
+	t.Run("next cursor", func(t *testing.T) {
+		eventDate := time.Date(2025, time.February, 1, 10, 0, 0, 0, time.UTC)
+		rows := []*models.Transaction{{ID: 1}, {ID: 2, EventDate: eventDate}, {ID: 3}}
+		q := NewListQuery().OrderBy("event_date", false).Page(nil, 2)
+
+		page, next := nextCursor(db, q, rows)
+		assert.Len(t, page, 2)
+		assert.Equal(t, &Cursor{Sort: "event_date", Value: "2025-02-01T10:00:00Z", ID: 2}, next)
+
+		page, next = nextCursor(db, q, rows[:2])
+		assert.Len(t, page, 2)
+		assert.Nil(t, next)
+	})
+}
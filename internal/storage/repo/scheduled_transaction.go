@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type ScheduledTransaction interface {
+	Create(context.Context, *models.ScheduledTransaction) (*models.ScheduledTransaction, error)
+	GetByID(context.Context, int64) (*models.ScheduledTransaction, error)
+	GetByIDForUpdate(context.Context, int64) (*models.ScheduledTransaction, error)
+	GetDue(context.Context, time.Time, int) ([]*models.ScheduledTransaction, error)
+	UpdateSchedule(context.Context, *models.ScheduledTransaction) error
+	CreateExecution(context.Context, *models.ScheduledExecution) (*models.ScheduledExecution, error)
+}
+
+type scheduledTransaction struct {
+	*baseRepo[models.ScheduledTransaction]
+}
+
+func NewScheduledTransactionRepo(db bun.IDB) ScheduledTransaction {
+	return &scheduledTransaction{baseRepo: newBaseRepo[models.ScheduledTransaction](db)}
+}
+
+func (s *scheduledTransaction) Create(ctx context.Context, model *models.ScheduledTransaction) (*models.ScheduledTransaction, error) {
+	return s.baseRepo.Insert(ctx, model)
+}
+
+// GetByID fetches a ScheduledTransaction by ID along with its executions, in the order they were scheduled
+func (s *scheduledTransaction) GetByID(ctx context.Context, id int64) (*models.ScheduledTransaction, error) {
+	model := new(models.ScheduledTransaction)
+	err := s.conn(ctx).NewSelect().
+		Model(model).
+		Relation("Executions", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("scheduled_for ASC")
+		}).
+		Where("?TableAlias.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// GetByIDForUpdate fetches a ScheduledTransaction by ID and locks its row until the surrounding transaction ends
+func (s *scheduledTransaction) GetByIDForUpdate(ctx context.Context, id int64) (*models.ScheduledTransaction, error) {
+	return s.baseRepo.LockByID(ctx, id)
+}
+
+// GetDue fetches at most limit active ScheduledTransactions whose next occurrence is at or before now, earliest first
+func (s *scheduledTransaction) GetDue(ctx context.Context, now time.Time, limit int) ([]*models.ScheduledTransaction, error) {
+	var scheduled []*models.ScheduledTransaction
+	err := s.conn(ctx).NewSelect().
+		Model(&scheduled).
+		Where("status = ?", models.ScheduleActive).
+		Where("next_run_at <= ?", now).
+		OrderExpr("next_run_at ASC, id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// UpdateSchedule saves the occurrences, next occurrence and status of a ScheduledTransaction
+func (s *scheduledTransaction) UpdateSchedule(ctx context.Context, model *models.ScheduledTransaction) error {
+	return s.baseRepo.UpdateColumns(ctx, model, "occurrences", "next_run_at", "status")
+}
+
+func (s *scheduledTransaction) CreateExecution(ctx context.Context, model *models.ScheduledExecution) (*models.ScheduledExecution, error) {
+	if _, err := s.conn(ctx).NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
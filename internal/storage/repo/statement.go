@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type Statement interface {
+	Create(context.Context, *models.Statement) (*models.Statement, error)
+	GetByID(context.Context, int64) (*models.Statement, error)
+	GetByAccountID(context.Context, int64, *ListQuery) ([]*models.Statement, *Cursor, error)
+	GetLatest(context.Context, int64) (*models.Statement, error)
+	GetDueOn(context.Context, int64, time.Time) (*models.Statement, error)
+	UpdateTotals(context.Context, *models.Statement) error
+}
+
+type statement struct {
+	*baseRepo[models.Statement]
+}
+
+func NewStatementRepo(db bun.IDB) Statement {
+	return &statement{baseRepo: newBaseRepo[models.Statement](db)}
+}
+
+func (s *statement) Create(ctx context.Context, model *models.Statement) (*models.Statement, error) {
+	return s.baseRepo.Insert(ctx, model)
+}
+
+// GetByID fetches a Statement by ID along with its transactions, in the order they were booked
+func (s *statement) GetByID(ctx context.Context, id int64) (*models.Statement, error) {
+	model := new(models.Statement)
+	err := s.conn(ctx).NewSelect().
+		Model(model).
+		Relation("Transactions", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("transaction.event_date ASC, transaction.id ASC")
+		}).
+		Where("?TableAlias.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// GetByAccountID fetches one page of the Statements of an account
+func (s *statement) GetByAccountID(ctx context.Context, accountID int64, q *ListQuery) ([]*models.Statement, *Cursor, error) {
+	return s.baseRepo.FindByColumn(ctx, accountID, "account_id", q)
+}
+
+// GetLatest fetches the Statement of the last closed cycle of an account
+// Returns sql.ErrNoRows when no cycle of the account was closed yet
+func (s *statement) GetLatest(ctx context.Context, accountID int64) (*models.Statement, error) {
+	model := new(models.Statement)
+	err := s.conn(ctx).NewSelect().
+		Model(model).
+		Where("account_id = ?", accountID).
+		OrderExpr("period_end DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// GetDueOn fetches the Statement of an account due on a day
+// Returns sql.ErrNoRows when none is, cycles being monthly there is at most one
+func (s *statement) GetDueOn(ctx context.Context, accountID int64, day time.Time) (*models.Statement, error) {
+	model := new(models.Statement)
+	err := s.conn(ctx).NewSelect().
+		Model(model).
+		Where("account_id = ?", accountID).
+		Where("due_date = ?", day.Format(time.DateOnly)).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// UpdateTotals persists the totals, balances and minimum payment of a Statement once its transactions are frozen
+func (s *statement) UpdateTotals(ctx context.Context, model *models.Statement) error {
+	return s.baseRepo.UpdateColumns(ctx, model, "total_credits", "total_debits", "closing_balance", "minimum_payment")
+}
@@ -2,14 +2,36 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
 
 	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/shopspring/decimal"
 	"github.com/uptrace/bun"
 )
 
 type Transaction interface {
 	Create(context.Context, *models.Transaction) (*models.Transaction, error)
-	GetAllTransactions(context.Context) ([]*models.Transaction, error)
+	List(context.Context, *ListQuery) ([]*models.Transaction, *Cursor, error)
+	GetByAccountID(context.Context, int64, *ListQuery) ([]*models.Transaction, *Cursor, error)
+	GetByID(context.Context, int64, bool) (*models.Transaction, error)
+	GetByIDForUpdate(context.Context, int64) (*models.Transaction, error)
+	GetRefundedAmount(context.Context, int64) (decimal.Decimal, error)
+	GetBalance(context.Context, int64) (*models.Balance, error)
+	HasPending(context.Context, int64) (bool, error)
+	ComputeBalance(context.Context, int64) (*models.Balance, error)
+	GetBalanceDrifts(context.Context) ([]*models.BalanceDrift, error)
+	RebuildBalance(context.Context, int64) (*models.Balance, error)
+	GetOpenDebits(context.Context, int64) ([]*models.Transaction, error)
+	UpdateBalance(context.Context, *models.Transaction) error
+	UpdateStatus(context.Context, *models.Transaction) error
+	Settle(context.Context, *models.Transaction) error
+	FreezeForStatement(context.Context, *models.Statement) (*models.Balance, error)
+	GetOverdueDebits(context.Context, int64, time.Time) ([]*models.Transaction, error)
+	GetPaidAmount(context.Context, int64, time.Time, time.Time) (decimal.Decimal, error)
+	RunInTx(context.Context, *sql.TxOptions, func(context.Context, bun.Tx) error) error
 }
 
 type transaction struct {
@@ -20,11 +42,257 @@ func NewTransactionRepo(db bun.IDB) Transaction {
 	return &transaction{baseRepo: newBaseRepo[models.Transaction](db)}
 }
 
+// Create inserts a Transaction and, when it is posted, adds its amount to the balance of the account
+// in the same DB transaction
 func (a *transaction) Create(ctx context.Context, model *models.Transaction) (*models.Transaction, error) {
-	return a.baseRepo.Insert(ctx, model)
+	err := a.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		if _, err := a.baseRepo.Insert(ctx, model); err != nil {
+			return err
+		}
+		return a.addToBalance(ctx, model)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
 }
 
-// GetAllTransactions fetches all customer Transactions
-func (a *transaction) GetAllTransactions(ctx context.Context) ([]*models.Transaction, error) {
-	return a.baseRepo.GetAll(ctx, "")
+// addToBalance adds the amount of a posted transaction to the running totals of its account
+func (a *transaction) addToBalance(ctx context.Context, model *models.Transaction) error {
+	if !slices.Contains(models.PostedStatuses, model.Status) || model.Amount.IsZero() {
+		return nil
+	}
+	balance := &models.Balance{AccountID: model.AccountID, UpdatedAt: time.Now().UTC()}
+	balance.Add(model.Amount.Decimal)
+	_, err := a.conn(ctx).NewInsert().
+		Model(balance).
+		On("CONFLICT (account_id) DO UPDATE").
+		Set("total_credits = balance.total_credits + EXCLUDED.total_credits").
+		Set("total_debits = balance.total_debits + EXCLUDED.total_debits").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return err
+}
+
+// List fetches one page of customer Transactions matching the query
+func (a *transaction) List(ctx context.Context, q *ListQuery) ([]*models.Transaction, *Cursor, error) {
+	return a.baseRepo.List(ctx, q)
+}
+
+// GetByAccountID fetches one page of the Transactions of an account
+func (a *transaction) GetByAccountID(ctx context.Context, accountID int64, q *ListQuery) ([]*models.Transaction, *Cursor, error) {
+	return a.baseRepo.FindByColumn(ctx, accountID, "account_id", q)
+}
+
+// GetByID fetches a Transaction by ID, along with its installments if associations is set
+func (a *transaction) GetByID(ctx context.Context, id int64, associations bool) (*models.Transaction, error) {
+	if associations {
+		return a.baseRepo.FindByID(ctx, id, "Installments")
+	}
+	return a.baseRepo.FindByID(ctx, id, "")
+}
+
+// GetByIDForUpdate fetches a Transaction by ID and locks its row until the surrounding transaction ends
+func (a *transaction) GetByIDForUpdate(ctx context.Context, id int64) (*models.Transaction, error) {
+	return a.baseRepo.LockByID(ctx, id)
+}
+
+// GetRefundedAmount sums the amounts of the compensating transactions linked to a transaction
+func (a *transaction) GetRefundedAmount(ctx context.Context, id int64) (decimal.Decimal, error) {
+	var refunded decimal.Decimal
+	err := a.conn(ctx).NewSelect().
+		Model((*models.Transaction)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)").
+		Where("reversed_transaction_id = ?", id).
+		Scan(ctx, &refunded)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return refunded, nil
+}
+
+// GetBalance fetches the running totals of an account's posted transactions, an account without any has a zero balance
+func (a *transaction) GetBalance(ctx context.Context, accountID int64) (*models.Balance, error) {
+	balance := &models.Balance{AccountID: accountID}
+	if err := a.conn(ctx).NewSelect().Model(balance).WherePK().Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &models.Balance{AccountID: accountID}, nil
+		}
+		return nil, err
+	}
+	return balance, nil
+}
+
+// HasPending tells whether an account has pending authorizations, which the balance does not count
+func (a *transaction) HasPending(ctx context.Context, accountID int64) (bool, error) {
+	return a.conn(ctx).NewSelect().
+		Model((*models.Transaction)(nil)).
+		Where("account_id = ?", accountID).
+		Where("status = ?", models.TxnStatusPending).
+		Exists(ctx)
+}
+
+// ComputeBalance aggregates the signed amounts of an account's posted transactions from scratch
+// Debits are stored as negative amounts and credits as positive, so both totals are computed in a single scan
+func (a *transaction) ComputeBalance(ctx context.Context, accountID int64) (*models.Balance, error) {
+	balance := &models.Balance{AccountID: accountID}
+	err := a.conn(ctx).NewSelect().
+		Model((*models.Transaction)(nil)).
+		ColumnExpr("COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0) AS total_credits").
+		ColumnExpr("COALESCE(SUM(amount) FILTER (WHERE amount < 0), 0) AS total_debits").
+		Where("account_id = ?", accountID).
+		Where("status IN (?)", bun.In(models.PostedStatuses)).
+		Scan(ctx, &balance.TotalCredits, &balance.TotalDebits)
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// GetBalanceDrifts compares the stored balance of every account with the one computed from its transactions
+// and returns the accounts where they differ. Both come from the same snapshot, so transactions being booked
+// concurrently cannot show up as drifts
+func (a *transaction) GetBalanceDrifts(ctx context.Context) ([]*models.BalanceDrift, error) {
+	var drifts []*models.BalanceDrift
+	err := a.conn(ctx).NewSelect().
+		TableExpr("accounts AS a").
+		Join("LEFT JOIN account_balances AS b ON b.account_id = a.id").
+		Join(`LEFT JOIN (
+			SELECT account_id,
+				SUM(amount) FILTER (WHERE amount > 0) AS credits,
+				SUM(amount) FILTER (WHERE amount < 0) AS debits
+			FROM transactions WHERE status IN (?) GROUP BY account_id
+		) AS t ON t.account_id = a.id`, bun.In(models.PostedStatuses)).
+		ColumnExpr("a.id AS account_id").
+		ColumnExpr("COALESCE(b.total_credits, 0) AS stored_credits, COALESCE(b.total_debits, 0) AS stored_debits").
+		ColumnExpr("COALESCE(t.credits, 0) AS computed_credits, COALESCE(t.debits, 0) AS computed_debits").
+		Where("COALESCE(b.total_credits, 0) <> COALESCE(t.credits, 0) OR COALESCE(b.total_debits, 0) <> COALESCE(t.debits, 0)").
+		OrderExpr("a.id ASC").
+		Scan(ctx, &drifts)
+	if err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
+
+// RebuildBalance recomputes the balance of an account from its transactions and overwrites the stored one
+// The account row should be locked by the caller so no transaction is booked meanwhile
+func (a *transaction) RebuildBalance(ctx context.Context, accountID int64) (*models.Balance, error) {
+	balance, err := a.ComputeBalance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	balance.UpdatedAt = time.Now().UTC()
+	_, err = a.conn(ctx).NewInsert().
+		Model(balance).
+		On("CONFLICT (account_id) DO UPDATE").
+		Set("total_credits = EXCLUDED.total_credits").
+		Set("total_debits = EXCLUDED.total_debits").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// GetOpenDebits fetches the posted debits of an account that are not fully discharged yet, oldest first
+// Rows are locked (FOR UPDATE) so concurrent credits cannot discharge the same debit twice
+func (a *transaction) GetOpenDebits(ctx context.Context, accountID int64) ([]*models.Transaction, error) {
+	var debits []*models.Transaction
+	err := a.conn(ctx).NewSelect().
+		Model(&debits).
+		Where("account_id = ?", accountID).
+		Where("status IN (?)", bun.In(models.PostedStatuses)).
+		Where("balance < 0").
+		OrderExpr("event_date ASC, id ASC").
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return debits, nil
+}
+
+// UpdateBalance persists the remaining balance of a transaction
+func (a *transaction) UpdateBalance(ctx context.Context, model *models.Transaction) error {
+	return a.baseRepo.UpdateColumns(ctx, model, "balance")
+}
+
+// UpdateStatus persists the status of a transaction
+func (a *transaction) UpdateStatus(ctx context.Context, model *models.Transaction) error {
+	return a.baseRepo.UpdateColumns(ctx, model, "status")
+}
+
+// Settle persists the amount, original amount, balance and status of an authorization once it is captured or voided
+// A captured amount becomes posted and is added to the balance of the account in the same DB transaction
+func (a *transaction) Settle(ctx context.Context, model *models.Transaction) error {
+	return a.RunInTx(ctx, nil, func(ctx context.Context, _ bun.Tx) error {
+		if err := a.baseRepo.UpdateColumns(ctx, model, "amount", "original_amount", "balance", "status"); err != nil {
+			return err
+		}
+		return a.addToBalance(ctx, model)
+	})
+}
+
+// FreezeForStatement links the posted transactions of an account booked before the end of the statement period and not
+// frozen into a previous statement to the statement, and returns their totals
+// A debit authorized in a previous cycle but captured in this one is frozen here, with its captured amount
+func (a *transaction) FreezeForStatement(ctx context.Context, model *models.Statement) (*models.Balance, error) {
+	var amounts []decimal.Decimal
+	_, err := a.conn(ctx).NewUpdate().
+		Model((*models.Transaction)(nil)).
+		Set("statement_id = ?", model.ID).
+		Where("account_id = ?", model.AccountID).
+		Where("statement_id IS NULL").
+		Where("status IN (?)", bun.In(models.PostedStatuses)).
+		Where("event_date < ?", model.PeriodEnd).
+		Returning("amount").
+		Exec(ctx, &amounts)
+	if err != nil {
+		return nil, err
+	}
+	frozen := &models.Balance{AccountID: model.AccountID}
+	for _, amount := range amounts {
+		frozen.Add(amount)
+	}
+	return frozen, nil
+}
+
+// GetOverdueDebits fetches the open debits of an account frozen into a statement due before the day, along with their
+// operation type, only the ones of a type accruing interest
+func (a *transaction) GetOverdueDebits(ctx context.Context, accountID int64, day time.Time) ([]*models.Transaction, error) {
+	var debits []*models.Transaction
+	err := a.conn(ctx).NewSelect().
+		Model(&debits).
+		Relation("OperationType").
+		Join("JOIN statements AS s ON s.id = transaction.statement_id").
+		Where("transaction.account_id = ?", accountID).
+		Where("transaction.status IN (?)", bun.In(models.PostedStatuses)).
+		Where("transaction.balance < 0").
+		Where("s.due_date < ?", day.Format(time.DateOnly)).
+		Where("operation_type.interest_rate > 0").
+		OrderExpr("transaction.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return debits, nil
+}
+
+// GetPaidAmount sums the posted credits of an account booked from (inclusive) to (exclusive)
+func (a *transaction) GetPaidAmount(ctx context.Context, accountID int64, from, to time.Time) (decimal.Decimal, error) {
+	var paid decimal.Decimal
+	err := a.conn(ctx).NewSelect().
+		Model((*models.Transaction)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)").
+		Where("account_id = ?", accountID).
+		Where("status IN (?)", bun.In(models.PostedStatuses)).
+		Where("amount > 0").
+		Where("event_date >= ? AND event_date < ?", from, to).
+		Scan(ctx, &paid)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return paid, nil
 }
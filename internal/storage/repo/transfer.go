@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/akhiltak/pismo-api/internal/storage/models"
+	"github.com/uptrace/bun"
+)
+
+type Transfer interface {
+	Create(context.Context, *models.Transfer) (*models.Transfer, error)
+	GetByID(context.Context, int64) (*models.Transfer, error)
+}
+
+type transfer struct {
+	*baseRepo[models.Transfer]
+}
+
+func NewTransferRepo(db bun.IDB) Transfer {
+	return &transfer{baseRepo: newBaseRepo[models.Transfer](db)}
+}
+
+func (t *transfer) Create(ctx context.Context, model *models.Transfer) (*models.Transfer, error) {
+	return t.baseRepo.Insert(ctx, model)
+}
+
+// GetByID fetches a Transfer by ID along with its debit and credit, in that order
+func (t *transfer) GetByID(ctx context.Context, id int64) (*models.Transfer, error) {
+	model := new(models.Transfer)
+	err := t.conn(ctx).NewSelect().
+		Model(model).
+		Relation("Transactions", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.OrderExpr("id ASC")
+		}).
+		Where("?TableAlias.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DateLayout is the layout used for calendar dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date (without time of day) serialized as YYYY-MM-DD
+type Date struct {
+	time.Time
+} // @name Date
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(DateLayout))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalParam(s)
+}
+
+// UnmarshalParam lets echo bind dates from query and path params
+func (d *Date) UnmarshalParam(param string) error {
+	t, err := time.Parse(DateLayout, param)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
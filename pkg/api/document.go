@@ -0,0 +1,56 @@
+package api
+
+import "strings"
+
+var cpfWeights = []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+var cnpjWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// NormalizeDocument strips the punctuation of a formatted CPF (123.456.789-09) or CNPJ (12.345.678/0001-95)
+func NormalizeDocument(doc string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', '/', ' ':
+			return -1
+		}
+		return r
+	}, doc)
+}
+
+// IsCPF tells whether doc is a CPF (individual taxpayer number) with valid check digits, punctuation is ignored
+func IsCPF(doc string) bool {
+	return hasCheckDigits(NormalizeDocument(doc), 11, cpfWeights)
+}
+
+// IsCNPJ tells whether doc is a CNPJ (company taxpayer number) with valid check digits, punctuation is ignored
+func IsCNPJ(doc string) bool {
+	return hasCheckDigits(NormalizeDocument(doc), 14, cnpjWeights)
+}
+
+// hasCheckDigits verifies the two trailing mod 11 check digits of a document of the given length
+// The weights of the second digit are the full list, the first digit uses the list without its first weight
+// Documents made of a single repeated digit pass the check but are not valid
+func hasCheckDigits(doc string, length int, weights []int) bool {
+	if len(doc) != length || strings.Count(doc, doc[:1]) == length {
+		return false
+	}
+	digits := make([]int, length)
+	for i, r := range doc {
+		if r < '0' || r > '9' {
+			return false
+		}
+		digits[i] = int(r - '0')
+	}
+	return checkDigit(digits[:length-2], weights[1:]) == digits[length-2] &&
+		checkDigit(digits[:length-1], weights) == digits[length-1]
+}
+
+func checkDigit(digits []int, weights []int) int {
+	sum := 0
+	for i, d := range digits {
+		sum += d * weights[i]
+	}
+	if rest := sum % 11; rest >= 2 {
+		return 11 - rest
+	}
+	return 0
+}
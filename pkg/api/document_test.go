@@ -0,0 +1,35 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDocument(t *testing.T) {
+	assert.Equal(t, "52998224725", NormalizeDocument("529.982.247-25"))
+	assert.Equal(t, "11222333000181", NormalizeDocument("11.222.333/0001-81"))
+	assert.Equal(t, "abc", NormalizeDocument("abc"))
+}
+
+func TestIsCPF(t *testing.T) {
+	assert.True(t, IsCPF("52998224725"))
+	assert.True(t, IsCPF("529.982.247-25"))
+	assert.True(t, IsCPF("12345678909"))
+	assert.False(t, IsCPF("52998224726"))    // wrong second check digit
+	assert.False(t, IsCPF("52998224715"))    // wrong first check digit
+	assert.False(t, IsCPF("11111111111"))    // repeated digits
+	assert.False(t, IsCPF("5299822472"))     // too short
+	assert.False(t, IsCPF("5299822472a"))    // not a number
+	assert.False(t, IsCPF("11222333000181")) // a CNPJ
+	assert.False(t, IsCPF(""))
+}
+
+func TestIsCNPJ(t *testing.T) {
+	assert.True(t, IsCNPJ("11222333000181"))
+	assert.True(t, IsCNPJ("11.222.333/0001-81"))
+	assert.False(t, IsCNPJ("11222333000182"))
+	assert.False(t, IsCNPJ("00000000000000"))
+	assert.False(t, IsCNPJ("52998224725"))
+	assert.False(t, IsCNPJ("abc"))
+}
@@ -1,13 +1,179 @@
 package api
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
 
+	"github.com/shopspring/decimal"
+)
+
+// CreateAccountRequest opens an account for an existing customer (customer_id) or for the customer
+// of a document number, which is created on the fly if unknown. Only one of the two can be given.
 type CreateAccountRequest struct {
-	DocNum string `json:"document_number" validate:"required"`
+	CustomerID           int64             `json:"customer_id,omitempty" validate:"required_without=DocNum"`
+	DocNum               string            `json:"document_number,omitempty" validate:"omitempty,cpf|cnpj"`                 // CPF or CNPJ, punctuation is stripped
+	Currency             string            `json:"currency,omitempty" validate:"omitempty,len=3"`                           // ISO 4217, defaults to BRL
+	AvailableCreditLimit *Money            `json:"available_credit_limit,omitempty" swaggertype:"string" example:"1000.00"` // optional, debits are not limited when omitted
+	Metadata             map[string]string `json:"metadata,omitempty" validate:"omitempty,max=50,dive,keys,min=1,max=64,endkeys,max=255"`
+	ClosingDay           int               `json:"closing_day,omitempty" validate:"omitempty,min=1,max=28"` // day of the month the billing cycle closes, defaults to 1
+	DueDays              int               `json:"due_days,omitempty" validate:"omitempty,min=1,max=60"`    // days from the closing to the due date, defaults to 10
 } // @name CreateAccountRequest
 
+// ListAccountsRequest holds the filters and pagination of GET /accounts
+type ListAccountsRequest struct {
+	DocNum   string            `query:"document_number"` // CPF or CNPJ, formatted or not
+	Metadata map[string]string `query:"-"`               // metadata[key]=value pairs, all of them have to match
+	Cursor   string            `query:"cursor"`          // next_cursor of the previous page
+	Limit    int               `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// UpdateAccountRequest changes the status, the metadata or both, at least one of them has to be given
+type UpdateAccountRequest struct {
+	Status   string            `json:"status,omitempty" validate:"omitempty,oneof=active blocked closed"`
+	Reason   string            `json:"reason,omitempty" validate:"required_with=Status,max=255"`                              // why the status changed, kept on the account
+	Metadata map[string]string `json:"metadata,omitempty" validate:"omitempty,max=50,dive,keys,min=1,max=64,endkeys,max=255"` // replaces the whole metadata, {} clears it
+} // @name UpdateAccountRequest
+
+type CreateCustomerRequest struct {
+	DocNum    string `json:"document_number" validate:"required,cpf|cnpj"` // CPF or CNPJ, punctuation is stripped
+	Name      string `json:"name" validate:"required,max=255"`
+	BirthDate *Date  `json:"birth_date" validate:"required" swaggertype:"string" format:"date" example:"1990-05-17"`
+} // @name CreateCustomerRequest
+
+// ListCustomerAccountsRequest holds the pagination of GET /customers/:id/accounts
+type ListCustomerAccountsRequest struct {
+	Cursor string `query:"cursor"` // next_cursor of the previous page
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type EraseCustomerRequest struct {
+	RequestedBy string `json:"requested_by" validate:"required,max=255"` // who requested the erasure, kept for audit
+} // @name EraseCustomerRequest
+
+type ListOperationTypesRequest struct {
+	Active *bool `query:"active"` // optional, only active or inactive types
+}
+
+type OperationTypeRequest struct {
+	Description  string `json:"description" validate:"required,max=255"`
+	EntryType    string `json:"type" validate:"required"` // credit or debit
+	Installments bool   `json:"installments"`             // whether the amount can be split in installments
+	// monthly interest rate (0.12 for 12%) charged on what is still owed of the debits of the type once their statement is past due
+	InterestRate *decimal.Decimal `json:"interest_rate,omitempty" swaggertype:"string" example:"0.12"`
+} // @name OperationTypeRequest
+
 type CreateTransactionRequest struct {
-	AccountID       int64           `json:"account_id" validate:"required"`
-	OperationTypeID int64           `json:"operation_type_id" validate:"required"`
-	Amount          decimal.Decimal `json:"amount" validate:"required"`
+	AccountID       int64  `json:"account_id" validate:"required"`
+	OperationTypeID int64  `json:"operation_type_id" validate:"required"`
+	Amount          Money  `json:"amount" validate:"required" swaggertype:"string" example:"100.50"`
+	Currency        string `json:"currency,omitempty" validate:"omitempty,len=3"` // ISO 4217, defaults to the currency of the account, another one is converted with the FX rate valid now
+	// installments are only accepted for operation types that allow them
+	InstallmentCount int   `json:"installment_count,omitempty" validate:"omitempty,min=1,max=72"`
+	FirstDueDate     *Date `json:"first_due_date,omitempty" swaggertype:"string" format:"date" example:"2025-03-10"` // defaults to one month after the purchase
+	// capture defaults to true, false only authorizes a debit which has to be captured or voided later
+	Capture *bool `json:"capture,omitempty"`
 } // @name CreateTransactionRequest
+
+// ListTransactionsRequest holds the optional filters and pagination of GET /transactions
+type ListTransactionsRequest struct {
+	AccountID       int64      `query:"account_id"`
+	OperationTypeID int64      `query:"operation_type_id"`
+	Status          string     `query:"status"`
+	EventDateFrom   *time.Time `query:"event_date_from"` // RFC3339, inclusive
+	EventDateTo     *time.Time `query:"event_date_to"`   // RFC3339, inclusive
+	MinAmount       *Money     `query:"min_amount"`      // absolute amount, inclusive
+	MaxAmount       *Money     `query:"max_amount"`      // absolute amount, inclusive
+	Cursor          string     `query:"cursor"`          // next_cursor of the previous page
+	Limit           int        `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ListAccountTransactionsRequest holds the sorting and pagination of GET /accounts/:id/transactions
+type ListAccountTransactionsRequest struct {
+	Sort   string `query:"sort" validate:"omitempty,oneof=id -id event_date -event_date amount -amount"` // prefix with - for descending
+	Embed  string `query:"embed" validate:"omitempty,oneof=operation_type"`
+	Cursor string `query:"cursor"` // next_cursor of the previous page, only valid with the same sort
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ListStatementsRequest holds the pagination of GET /accounts/:id/statements
+type ListStatementsRequest struct {
+	Cursor string `query:"cursor"` // next_cursor of the previous page
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Page is one page of a cursor paginated list, NextCursor is empty on the last page
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+} // @name Page
+
+type ReverseTransactionRequest struct {
+	Amount Money `json:"amount" swaggertype:"string" example:"10.00"` // optional, defaults to the full amount left to refund
+} // @name ReverseTransactionRequest
+
+type CaptureTransactionRequest struct {
+	Amount Money `json:"amount" swaggertype:"string" example:"10.00"` // optional, defaults to the authorized amount
+} // @name CaptureTransactionRequest
+
+// CreateTransferRequest moves an amount from one account to another, both accounts have to be in the same currency
+type CreateTransferRequest struct {
+	SourceAccountID      int64  `json:"source_account_id" validate:"required"`
+	DestinationAccountID int64  `json:"destination_account_id" validate:"required"`
+	Amount               Money  `json:"amount" validate:"required" swaggertype:"string" example:"100.50"` // positive, in the currency of the accounts
+	Description          string `json:"description,omitempty" validate:"max=255"`
+} // @name CreateTransferRequest
+
+// CreateScheduledTransactionRequest schedules a transaction booked later by the server, once at start_at or recurring
+// Every occurrence is booked like a CreateTransactionRequest with the same account, operation type, amount and currency
+type CreateScheduledTransactionRequest struct {
+	AccountID       int64      `json:"account_id" validate:"required"`
+	OperationTypeID int64      `json:"operation_type_id" validate:"required"`
+	Amount          Money      `json:"amount" validate:"required" swaggertype:"string" example:"100.50"`                       // positive, signed by the operation type
+	Currency        string     `json:"currency,omitempty" validate:"omitempty,len=3"`                                          // ISO 4217, defaults to the currency of the account
+	Frequency       string     `json:"frequency" validate:"required" enums:"once,daily,weekly,monthly,cron" example:"monthly"` // how often the transaction is booked
+	CronExpression  string     `json:"cron_expression,omitempty" validate:"max=255" example:"0 9 * * 1-5"`                     // 5 field cron expression in UTC, required by the cron frequency only
+	StartAt         *time.Time `json:"start_at,omitempty"`                                                                     // RFC3339, first occurrence (or the earliest one with a cron expression), defaults to now
+	EndAt           *time.Time `json:"end_at,omitempty"`                                                                       // RFC3339, no occurrence after it when set
+	MaxOccurrences  *int       `json:"max_occurrences,omitempty" validate:"omitempty,min=1"`                                   // no more occurrences than this when set
+} // @name CreateScheduledTransactionRequest
+
+// CreateFXRateRequest adds the rate of a currency pair for a validity window, it closes the open ended window of
+// the previous rate of the pair if that one started earlier
+type CreateFXRateRequest struct {
+	BaseCurrency  string          `json:"base_currency" validate:"required,len=3"`                        // ISO 4217, currency converted from (e.g. of a purchase abroad)
+	QuoteCurrency string          `json:"quote_currency" validate:"required,len=3"`                       // ISO 4217, currency converted to (e.g. of the account)
+	Rate          decimal.Decimal `json:"rate" validate:"required" swaggertype:"string" example:"5.4321"` // units of quote currency for one unit of base currency
+	ValidFrom     *time.Time      `json:"valid_from,omitempty"`                                           // RFC3339, defaults to now
+	ValidTo       *time.Time      `json:"valid_to,omitempty"`                                             // RFC3339, exclusive, open ended when empty
+} // @name CreateFXRateRequest
+
+// ListFXRatesRequest holds the filters and pagination of GET /fx-rates
+type ListFXRatesRequest struct {
+	BaseCurrency  string     `query:"base_currency"`
+	QuoteCurrency string     `query:"quote_currency"`
+	At            *time.Time `query:"at"`     // RFC3339, only the rates valid at that time
+	Cursor        string     `query:"cursor"` // next_cursor of the previous page
+	Limit         int        `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type AccountBalanceResponse struct {
+	AccountID    int64  `json:"account_id"`
+	Currency     string `json:"currency"`                           // ISO 4217 currency of the account
+	Balance      Money  `json:"balance" swaggertype:"string"`       // credits minus debits
+	TotalCredits Money  `json:"total_credits" swaggertype:"string"` // sum of all credit transactions
+	TotalDebits  Money  `json:"total_debits" swaggertype:"string"`  // sum of all debit transactions (absolute value)
+} // @name AccountBalanceResponse
+
+type CheckBalancesRequest struct {
+	Repair bool `json:"repair"` // rebuild the drifted balances from the transactions
+} // @name CheckBalancesRequest
+
+type BalanceDrift struct {
+	AccountID       int64 `json:"account_id"`
+	StoredBalance   Money `json:"stored_balance" swaggertype:"string"`   // balance read by GET /accounts/:id/balance
+	ComputedBalance Money `json:"computed_balance" swaggertype:"string"` // balance summed from the transactions
+} // @name BalanceDrift
+
+type CheckBalancesResponse struct {
+	Drifts   []*BalanceDrift `json:"drifts"`   // accounts whose stored balance is wrong, empty when all are consistent
+	Repaired bool            `json:"repaired"` // whether the drifted balances were rebuilt
+} // @name CheckBalancesResponse
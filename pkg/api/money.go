@@ -0,0 +1,6 @@
+package api
+
+import "github.com/akhiltak/pismo-api/pkg/money"
+
+// Money is the exact amount of the requests and responses, a JSON string such as "100.50"
+type Money = money.Money
@@ -2,17 +2,77 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
 const (
-	ErrParsingID           string = "cannot parse ID, should be integer"
-	ErrValidationStructure string = "cannot validate structure"
-	ErrNotFound            string = "requested record not found"
-	ErrOpTypeNotFound      string = "operation type record not found"
-	InternalServerErr      string = "Somewhere something went wrong but don't worry, we are on it."
+	ErrParsingID                string = "cannot parse ID, should be integer"
+	ErrValidationStructure      string = "cannot validate structure"
+	ErrNotFound                 string = "requested record not found"
+	ErrOpTypeNotFound           string = "operation type record not found"
+	ErrOpTypeInactive           string = "operation type is no longer active"
+	ErrOpTypeReserved           string = "operation type is reserved to the service and cannot be used or changed directly"
+	ErrInvalidEntryType         string = "invalid operation type entry, should be credit or debit"
+	ErrEntryTypeInUse           string = "entry type of an operation type cannot change once transactions are booked with it"
+	ErrEntryTypeChanged         string = "entry type of the operation type changed while the transaction was being created"
+	ErrInvalidInterestRate      string = "interest rate is a monthly rate between 0 and 1 with at most 6 decimals, only debits accrue interest"
+	ErrDuplicateDocument        string = "a customer already exists for this document number"
+	ErrCustomerNotFound         string = "customer record not found"
+	ErrCustomerOrDocument       string = "either customer_id or document_number should be given, not both"
+	ErrCustomerErased           string = "customer personal data is erased, no account can be opened"
+	ErrCustomerAccountsOpen     string = "all accounts of the customer have to be closed before its personal data is erased"
+	ErrCustomerAlreadyErased    string = "customer personal data is already erased"
+	ErrNothingToUpdate          string = "nothing to update, please provide a status or metadata"
+	ErrInvalidAccountStatus     string = "invalid account status, should be active, blocked or closed"
+	ErrAccountStatusChange      string = "account cannot move to the requested status"
+	ErrAccountBalanceNotZero    string = "account cannot be closed while its balance is not zero"
+	ErrAccountPending           string = "account cannot be closed while it has pending authorizations, capture or void them first"
+	ErrAccountBlocked           string = "account is blocked, debits are not allowed"
+	ErrAccountClosed            string = "account is closed"
+	ErrAccountNotFound          string = "account record not found"
+	ErrInvalidCurrency          string = "invalid currency, should be an ISO 4217 code such as BRL"
+	ErrTransferSameAccount      string = "source and destination accounts of a transfer have to differ"
+	ErrTransferAmount           string = "transfer amount has to be positive"
+	ErrTransferCurrency         string = "source and destination accounts of a transfer have to be in the same currency"
+	ErrFXRateNotFound           string = "no FX rate converts the transaction currency into the currency of the account at this time"
+	ErrConvertedAmountZero      string = "amount is too small to be converted into the currency of the account"
+	ErrSameCurrencyPair         string = "base and quote currencies of an FX rate have to differ"
+	ErrInvalidFXRate            string = "FX rate has to be positive, with at most 8 decimals and 11 integer digits"
+	ErrInvalidValidityWindow    string = "valid_to has to be after valid_from"
+	ErrValidFromInPast          string = "valid_from cannot be in the past, conversions may already have used the rates valid then"
+	ErrFXRateOverlap            string = "another FX rate of the currency pair is valid during this window"
+	ErrAmountPrecision          string = "amount has more decimals than its currency allows"
+	ErrAmountTooLarge           string = "amount is too large, at most 15 integer digits are allowed"
+	ErrNegativeCreditLimit      string = "available credit limit cannot be negative"
+	ErrInsufficientLimit        string = "transaction amount exceeds the available credit limit of the account"
+	ErrInstallmentsNotAllowed   string = "operation type does not allow installments"
+	ErrInstallmentTooSmall      string = "amount is too small to be split in the requested number of installments"
+	ErrDueDateInPast            string = "first due date cannot be in the past"
+	ErrAlreadyReversed          string = "transaction is already fully reversed"
+	ErrReverseReversal          string = "a reversal cannot be reversed"
+	ErrReverseTransfer          string = "a transaction of a transfer cannot be reversed on its own, the other side would be kept"
+	ErrNotReversible            string = "only completed transactions can be reversed"
+	ErrAuthorizationNotAllowed  string = "only debits without installments can be authorized without capture"
+	ErrNotPending               string = "transaction is not a pending authorization"
+	ErrCaptureExceedsAmount     string = "capture amount exceeds the authorized amount"
+	ErrRefundExceedsAmount      string = "refund amount exceeds what is left to refund on the transaction"
+	ErrAccrualDayNotEnded       string = "only a day that has already ended can be accrued"
+	ErrInvalidFrequency         string = "invalid frequency, should be once, daily, weekly, monthly or cron"
+	ErrInvalidCronExpression    string = "invalid cron expression, should have 5 fields (minute hour day-of-month month day-of-week) and only be given with the cron frequency"
+	ErrScheduleStartInPast      string = "start_at cannot be in the past"
+	ErrScheduleEndBeforeStart   string = "end_at has to be after start_at"
+	ErrScheduleNeverRuns        string = "the schedule has no occurrence before end_at"
+	ErrScheduleAmount           string = "scheduled amount has to be positive"
+	ErrScheduleNotActive        string = "scheduled transaction is already completed or cancelled"
+	ErrInvalidCursor            string = "invalid cursor, please use the next_cursor of a previous page"
+	ErrInvalidStatus            string = "invalid transaction status"
+	ErrIdempotencyKeyTooLong    string = "Idempotency-Key header cannot be longer than 255 characters"
+	ErrIdempotencyKeyReused     string = "Idempotency-Key was already used for a different request"
+	ErrIdempotencyKeyInProgress string = "a request with the same Idempotency-Key is still being processed"
+	InternalServerErr           string = "Somewhere something went wrong but don't worry, we are on it."
 )
 
 type Response struct {
@@ -46,6 +106,24 @@ func NotFoundErr(msg string, err error) *echo.HTTPError {
 	return CustomErr(http.StatusNotFound, msg, err)
 }
 
+func ConflictErr(msg string, err error) *echo.HTTPError {
+	return CustomErr(http.StatusConflict, msg, err)
+}
+
+func UnprocessableEntityErr(msg string, err error) *echo.HTTPError {
+	return CustomErr(http.StatusUnprocessableEntity, msg, err)
+}
+
+// ClientErrMessage returns the message of an HTTP error of the client (4xx), i.e. of a request that would be rejected
+// again as is, and false for any other error
+func ClientErrMessage(err error) (string, bool) {
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code < http.StatusBadRequest || httpErr.Code >= http.StatusInternalServerError {
+		return "", false
+	}
+	return fmt.Sprint(httpErr.Message), true
+}
+
 func ServerErr(err error) *echo.HTTPError {
 	return CustomErr(http.StatusInternalServerError, InternalServerErr, err)
 }
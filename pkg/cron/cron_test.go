@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	from := time.Date(2025, time.February, 26, 10, 17, 30, 0, time.UTC) // a Wednesday
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.February, 26, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.February, 26, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2025, time.February, 27, 9, 0, 0, 0, time.UTC)},
+		{"30 8-18/2 * * *", time.Date(2025, time.February, 26, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 1,5", time.Date(2025, time.February, 28, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2025, time.March, 2, 12, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// day of month or day of week when both are restricted: the 1st or a Monday
+		{"0 0 1 * 1", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 4", time.Date(2025, time.February, 27, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, schedule.Next(from))
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@daily",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Parse(expr)
+			assert.Error(t, err)
+		})
+	}
+}
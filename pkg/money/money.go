@@ -0,0 +1,99 @@
+// Package money holds the exact amount type shared by the API and the storage models
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// Scale is the number of decimals of the NUMERIC(19,4) money columns
+	Scale = 4
+	// IntDigits is the number of digits left of the decimal point in the NUMERIC(19,4) money columns
+	IntDigits = 19 - Scale
+)
+
+// maxAmount is the first amount that does not fit the money columns
+var maxAmount = decimal.New(1, IntDigits)
+
+// Money is an exact amount serialized as a JSON string ("100.5") and stored as NUMERIC without going through float64
+// Amounts with more decimals or integer digits than the money columns hold are rejected when decoded
+type Money struct {
+	decimal.Decimal
+} // @name Money
+
+// New wraps the result of decimal arithmetic
+func New(d decimal.Decimal) Money {
+	return Money{Decimal: d}
+}
+
+// NewFromString parses an amount such as "100.50", it has to fit the money columns
+func NewFromString(s string) (Money, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	m := New(d)
+	if err := m.Check(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// Check reports whether the amount fits the NUMERIC(19,4) money columns
+func (m Money) Check() error {
+	if !m.Equal(m.Truncate(Scale)) {
+		return fmt.Errorf("amount %s has more than %d decimals", m, Scale)
+	}
+	if m.Abs().GreaterThanOrEqual(maxAmount) {
+		return fmt.Errorf("amount %s has more than %d integer digits", m, IntDigits)
+	}
+	return nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts both "100.50" and 100.50, numbers are parsed from their text so no precision is lost
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := NewFromString(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam lets echo bind amounts from query params
+func (m *Money) UnmarshalParam(param string) error {
+	parsed, err := NewFromString(param)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value sends the amount to the DB as text
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads the text of a NUMERIC column
+func (m *Money) Scan(value any) error {
+	return m.Decimal.Scan(value)
+}
@@ -0,0 +1,40 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyJSON(t *testing.T) {
+	var m Money
+	assert.NoError(t, json.Unmarshal([]byte(`"100.55"`), &m))
+	assert.True(t, decimal.RequireFromString("100.55").Equal(m.Decimal))
+	// numbers are parsed from their text, 0.1 stays exact
+	assert.NoError(t, json.Unmarshal([]byte(`0.1`), &m))
+	assert.Equal(t, "0.1", m.String())
+	assert.NoError(t, json.Unmarshal([]byte(`"-999999999999999.9999"`), &m))
+
+	out, err := json.Marshal(New(decimal.RequireFromString("-12.30")))
+	assert.NoError(t, err)
+	assert.Equal(t, `"-12.3"`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`"10.12345"`), &m))          // too many decimals
+	assert.Error(t, json.Unmarshal([]byte(`1000000000000000`), &m))    // too many integer digits
+	assert.Error(t, json.Unmarshal([]byte(`"-1000000000000000"`), &m)) // too many integer digits
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &m))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &m))
+}
+
+func TestMoneyDB(t *testing.T) {
+	m := New(decimal.RequireFromString("0.3"))
+	v, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3", v)
+
+	var scanned Money
+	assert.NoError(t, scanned.Scan([]byte("1234567890.1234")))
+	assert.Equal(t, "1234567890.1234", scanned.String())
+}